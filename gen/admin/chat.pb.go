@@ -0,0 +1,208 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v7.34.1
+// source: admin/chat.proto
+
+package admin
+
+import (
+	shared "github.com/charadev96/gonec/gen/shared"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BroadcastRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	UserIds       []string               `protobuf:"bytes,2,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	States        []UserState            `protobuf:"varint,3,rep,packed,name=states,proto3,enum=gonec.admin.v1.UserState" json:"states,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BroadcastRequest) Reset() {
+	*x = BroadcastRequest{}
+	mi := &file_admin_chat_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BroadcastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastRequest) ProtoMessage() {}
+
+func (x *BroadcastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_chat_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastRequest.ProtoReflect.Descriptor instead.
+func (*BroadcastRequest) Descriptor() ([]byte, []int) {
+	return file_admin_chat_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BroadcastRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *BroadcastRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *BroadcastRequest) GetStates() []UserState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+type BroadcastReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notice        *shared.Notice         `protobuf:"bytes,1,opt,name=notice,proto3" json:"notice,omitempty"`
+	Recipients    uint32                 `protobuf:"varint,2,opt,name=recipients,proto3" json:"recipients,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BroadcastReply) Reset() {
+	*x = BroadcastReply{}
+	mi := &file_admin_chat_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BroadcastReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastReply) ProtoMessage() {}
+
+func (x *BroadcastReply) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_chat_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastReply.ProtoReflect.Descriptor instead.
+func (*BroadcastReply) Descriptor() ([]byte, []int) {
+	return file_admin_chat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BroadcastReply) GetNotice() *shared.Notice {
+	if x != nil {
+		return x.Notice
+	}
+	return nil
+}
+
+func (x *BroadcastReply) GetRecipients() uint32 {
+	if x != nil {
+		return x.Recipients
+	}
+	return 0
+}
+
+var File_admin_chat_proto protoreflect.FileDescriptor
+
+const file_admin_chat_proto_rawDesc = "" +
+	"\n" +
+	"\x10admin/chat.proto\x12\x0egonec.admin.v1\x1a\x10admin/user.proto\x1a\x11shared/chat.proto\"z\n" +
+	"\x10BroadcastRequest\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\tR\auserIds\x121\n" +
+	"\x06states\x18\x03 \x03(\x0e2\x19.gonec.admin.v1.UserStateR\x06states\"a\n" +
+	"\x0eBroadcastReply\x12/\n" +
+	"\x06notice\x18\x01 \x01(\v2\x17.gonec.shared.v1.NoticeR\x06notice\x12\x1e\n" +
+	"\n" +
+	"recipients\x18\x02 \x01(\rR\n" +
+	"recipients2\\\n" +
+	"\vChatService\x12M\n" +
+	"\tBroadcast\x12 .gonec.admin.v1.BroadcastRequest\x1a\x1e.gonec.admin.v1.BroadcastReplyB'Z%github.com/charadev96/gonec/gen/adminb\x06proto3"
+
+var (
+	file_admin_chat_proto_rawDescOnce sync.Once
+	file_admin_chat_proto_rawDescData []byte
+)
+
+func file_admin_chat_proto_rawDescGZIP() []byte {
+	file_admin_chat_proto_rawDescOnce.Do(func() {
+		file_admin_chat_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_admin_chat_proto_rawDesc), len(file_admin_chat_proto_rawDesc)))
+	})
+	return file_admin_chat_proto_rawDescData
+}
+
+var file_admin_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_admin_chat_proto_goTypes = []any{
+	(*BroadcastRequest)(nil), // 0: gonec.admin.v1.BroadcastRequest
+	(*BroadcastReply)(nil),   // 1: gonec.admin.v1.BroadcastReply
+	(UserState)(0),           // 2: gonec.admin.v1.UserState
+	(*shared.Notice)(nil),    // 3: gonec.shared.v1.Notice
+}
+var file_admin_chat_proto_depIdxs = []int32{
+	2, // 0: gonec.admin.v1.BroadcastRequest.states:type_name -> gonec.admin.v1.UserState
+	3, // 1: gonec.admin.v1.BroadcastReply.notice:type_name -> gonec.shared.v1.Notice
+	0, // 2: gonec.admin.v1.ChatService.Broadcast:input_type -> gonec.admin.v1.BroadcastRequest
+	1, // 3: gonec.admin.v1.ChatService.Broadcast:output_type -> gonec.admin.v1.BroadcastReply
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_admin_chat_proto_init() }
+func file_admin_chat_proto_init() {
+	if File_admin_chat_proto != nil {
+		return
+	}
+	file_admin_user_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_admin_chat_proto_rawDesc), len(file_admin_chat_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_admin_chat_proto_goTypes,
+		DependencyIndexes: file_admin_chat_proto_depIdxs,
+		MessageInfos:      file_admin_chat_proto_msgTypes,
+	}.Build()
+	File_admin_chat_proto = out.File
+	file_admin_chat_proto_goTypes = nil
+	file_admin_chat_proto_depIdxs = nil
+}
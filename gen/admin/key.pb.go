@@ -0,0 +1,200 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v7.34.1
+// source: admin/key.proto
+
+package admin
+
+import (
+	shared "github.com/charadev96/gonec/gen/shared"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateKeyRolloverRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewPublicKey  []byte                 `protobuf:"bytes,1,opt,name=new_public_key,json=newPublicKey,proto3" json:"new_public_key,omitempty"`
+	NotBefore     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	NotAfter      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateKeyRolloverRequest) Reset() {
+	*x = CreateKeyRolloverRequest{}
+	mi := &file_admin_key_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateKeyRolloverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateKeyRolloverRequest) ProtoMessage() {}
+
+func (x *CreateKeyRolloverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_key_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateKeyRolloverRequest.ProtoReflect.Descriptor instead.
+func (*CreateKeyRolloverRequest) Descriptor() ([]byte, []int) {
+	return file_admin_key_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateKeyRolloverRequest) GetNewPublicKey() []byte {
+	if x != nil {
+		return x.NewPublicKey
+	}
+	return nil
+}
+
+func (x *CreateKeyRolloverRequest) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
+
+func (x *CreateKeyRolloverRequest) GetNotAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotAfter
+	}
+	return nil
+}
+
+type CreateKeyRolloverReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rollover      *shared.KeyRollover    `protobuf:"bytes,1,opt,name=rollover,proto3" json:"rollover,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateKeyRolloverReply) Reset() {
+	*x = CreateKeyRolloverReply{}
+	mi := &file_admin_key_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateKeyRolloverReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateKeyRolloverReply) ProtoMessage() {}
+
+func (x *CreateKeyRolloverReply) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_key_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateKeyRolloverReply.ProtoReflect.Descriptor instead.
+func (*CreateKeyRolloverReply) Descriptor() ([]byte, []int) {
+	return file_admin_key_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateKeyRolloverReply) GetRollover() *shared.KeyRollover {
+	if x != nil {
+		return x.Rollover
+	}
+	return nil
+}
+
+var File_admin_key_proto protoreflect.FileDescriptor
+
+const file_admin_key_proto_rawDesc = "" +
+	"\n" +
+	"\x0fadmin/key.proto\x12\x0egonec.admin.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x11shared/auth.proto\"\xb4\x01\n" +
+	"\x18CreateKeyRolloverRequest\x12$\n" +
+	"\x0enew_public_key\x18\x01 \x01(\fR\fnewPublicKey\x129\n" +
+	"\n" +
+	"not_before\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tnotBefore\x127\n" +
+	"\tnot_after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bnotAfter\"R\n" +
+	"\x16CreateKeyRolloverReply\x128\n" +
+	"\brollover\x18\x01 \x01(\v2\x1c.gonec.shared.v1.KeyRolloverR\brollover2s\n" +
+	"\n" +
+	"KeyService\x12e\n" +
+	"\x11CreateKeyRollover\x12(.gonec.admin.v1.CreateKeyRolloverRequest\x1a&.gonec.admin.v1.CreateKeyRolloverReplyB'Z%github.com/charadev96/gonec/gen/adminb\x06proto3"
+
+var (
+	file_admin_key_proto_rawDescOnce sync.Once
+	file_admin_key_proto_rawDescData []byte
+)
+
+func file_admin_key_proto_rawDescGZIP() []byte {
+	file_admin_key_proto_rawDescOnce.Do(func() {
+		file_admin_key_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_admin_key_proto_rawDesc), len(file_admin_key_proto_rawDesc)))
+	})
+	return file_admin_key_proto_rawDescData
+}
+
+var file_admin_key_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_admin_key_proto_goTypes = []any{
+	(*CreateKeyRolloverRequest)(nil), // 0: gonec.admin.v1.CreateKeyRolloverRequest
+	(*CreateKeyRolloverReply)(nil),   // 1: gonec.admin.v1.CreateKeyRolloverReply
+	(*timestamppb.Timestamp)(nil),    // 2: google.protobuf.Timestamp
+	(*shared.KeyRollover)(nil),       // 3: gonec.shared.v1.KeyRollover
+}
+var file_admin_key_proto_depIdxs = []int32{
+	2, // 0: gonec.admin.v1.CreateKeyRolloverRequest.not_before:type_name -> google.protobuf.Timestamp
+	2, // 1: gonec.admin.v1.CreateKeyRolloverRequest.not_after:type_name -> google.protobuf.Timestamp
+	3, // 2: gonec.admin.v1.CreateKeyRolloverReply.rollover:type_name -> gonec.shared.v1.KeyRollover
+	0, // 3: gonec.admin.v1.KeyService.CreateKeyRollover:input_type -> gonec.admin.v1.CreateKeyRolloverRequest
+	1, // 4: gonec.admin.v1.KeyService.CreateKeyRollover:output_type -> gonec.admin.v1.CreateKeyRolloverReply
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_admin_key_proto_init() }
+func file_admin_key_proto_init() {
+	if File_admin_key_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_admin_key_proto_rawDesc), len(file_admin_key_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_admin_key_proto_goTypes,
+		DependencyIndexes: file_admin_key_proto_depIdxs,
+		MessageInfos:      file_admin_key_proto_msgTypes,
+	}.Build()
+	File_admin_key_proto = out.File
+	file_admin_key_proto_goTypes = nil
+	file_admin_key_proto_depIdxs = nil
+}
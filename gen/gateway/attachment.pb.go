@@ -0,0 +1,534 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v7.34.1
+// source: gateway/attachment.proto
+
+package gateway
+
+import (
+	shared "github.com/charadev96/gonec/gen/shared"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type UploadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*UploadRequest_Open
+	//	*UploadRequest_Chunk
+	Payload       isUploadRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadRequest) Reset() {
+	*x = UploadRequest{}
+	mi := &file_gateway_attachment_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadRequest) ProtoMessage() {}
+
+func (x *UploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_attachment_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadRequest.ProtoReflect.Descriptor instead.
+func (*UploadRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_attachment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UploadRequest) GetPayload() isUploadRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *UploadRequest) GetOpen() *UploadOpen {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadRequest_Open); ok {
+			return x.Open
+		}
+	}
+	return nil
+}
+
+func (x *UploadRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadRequest_Payload interface {
+	isUploadRequest_Payload()
+}
+
+type UploadRequest_Open struct {
+	Open *UploadOpen `protobuf:"bytes,1,opt,name=open,proto3,oneof"`
+}
+
+type UploadRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadRequest_Open) isUploadRequest_Payload() {}
+
+func (*UploadRequest_Chunk) isUploadRequest_Payload() {}
+
+type UploadOpen struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Auth          *shared.Session        `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	MimeType      string                 `protobuf:"bytes,2,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadOpen) Reset() {
+	*x = UploadOpen{}
+	mi := &file_gateway_attachment_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadOpen) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadOpen) ProtoMessage() {}
+
+func (x *UploadOpen) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_attachment_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadOpen.ProtoReflect.Descriptor instead.
+func (*UploadOpen) Descriptor() ([]byte, []int) {
+	return file_gateway_attachment_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UploadOpen) GetAuth() *shared.Session {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *UploadOpen) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+type UploadReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachment    *shared.Attachment     `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadReply) Reset() {
+	*x = UploadReply{}
+	mi := &file_gateway_attachment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadReply) ProtoMessage() {}
+
+func (x *UploadReply) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_attachment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadReply.ProtoReflect.Descriptor instead.
+func (*UploadReply) Descriptor() ([]byte, []int) {
+	return file_gateway_attachment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UploadReply) GetAttachment() *shared.Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+type DownloadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Auth          *shared.Session        `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	AttachmentId  string                 `protobuf:"bytes,2,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadRequest) Reset() {
+	*x = DownloadRequest{}
+	mi := &file_gateway_attachment_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadRequest) ProtoMessage() {}
+
+func (x *DownloadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_attachment_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadRequest.ProtoReflect.Descriptor instead.
+func (*DownloadRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_attachment_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DownloadRequest) GetAuth() *shared.Session {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *DownloadRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+type DownloadReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*DownloadReply_Attachment
+	//	*DownloadReply_Chunk
+	Payload       isDownloadReply_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadReply) Reset() {
+	*x = DownloadReply{}
+	mi := &file_gateway_attachment_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadReply) ProtoMessage() {}
+
+func (x *DownloadReply) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_attachment_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadReply.ProtoReflect.Descriptor instead.
+func (*DownloadReply) Descriptor() ([]byte, []int) {
+	return file_gateway_attachment_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DownloadReply) GetPayload() isDownloadReply_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *DownloadReply) GetAttachment() *shared.Attachment {
+	if x != nil {
+		if x, ok := x.Payload.(*DownloadReply_Attachment); ok {
+			return x.Attachment
+		}
+	}
+	return nil
+}
+
+func (x *DownloadReply) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*DownloadReply_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isDownloadReply_Payload interface {
+	isDownloadReply_Payload()
+}
+
+type DownloadReply_Attachment struct {
+	Attachment *shared.Attachment `protobuf:"bytes,1,opt,name=attachment,proto3,oneof"`
+}
+
+type DownloadReply_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*DownloadReply_Attachment) isDownloadReply_Payload() {}
+
+func (*DownloadReply_Chunk) isDownloadReply_Payload() {}
+
+type AckDownloadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Auth          *shared.Session        `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	AttachmentId  string                 `protobuf:"bytes,2,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckDownloadRequest) Reset() {
+	*x = AckDownloadRequest{}
+	mi := &file_gateway_attachment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckDownloadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckDownloadRequest) ProtoMessage() {}
+
+func (x *AckDownloadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_attachment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckDownloadRequest.ProtoReflect.Descriptor instead.
+func (*AckDownloadRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_attachment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AckDownloadRequest) GetAuth() *shared.Session {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *AckDownloadRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+type AckDownloadReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckDownloadReply) Reset() {
+	*x = AckDownloadReply{}
+	mi := &file_gateway_attachment_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckDownloadReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckDownloadReply) ProtoMessage() {}
+
+func (x *AckDownloadReply) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_attachment_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckDownloadReply.ProtoReflect.Descriptor instead.
+func (*AckDownloadReply) Descriptor() ([]byte, []int) {
+	return file_gateway_attachment_proto_rawDescGZIP(), []int{6}
+}
+
+var File_gateway_attachment_proto protoreflect.FileDescriptor
+
+const file_gateway_attachment_proto_rawDesc = "" +
+	"\n" +
+	"\x18gateway/attachment.proto\x12\x10gonec.gateway.v1\x1a\x11shared/auth.proto\x1a\x11shared/chat.proto\"f\n" +
+	"\rUploadRequest\x122\n" +
+	"\x04open\x18\x01 \x01(\v2\x1c.gonec.gateway.v1.UploadOpenH\x00R\x04open\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"W\n" +
+	"\n" +
+	"UploadOpen\x12,\n" +
+	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\x12\x1b\n" +
+	"\tmime_type\x18\x02 \x01(\tR\bmimeType\"J\n" +
+	"\vUploadReply\x12;\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x1b.gonec.shared.v1.AttachmentR\n" +
+	"attachment\"d\n" +
+	"\x0fDownloadRequest\x12,\n" +
+	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\x12#\n" +
+	"\rattachment_id\x18\x02 \x01(\tR\fattachmentId\"q\n" +
+	"\rDownloadReply\x12=\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x1b.gonec.shared.v1.AttachmentH\x00R\n" +
+	"attachment\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"g\n" +
+	"\x12AckDownloadRequest\x12,\n" +
+	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\x12#\n" +
+	"\rattachment_id\x18\x02 \x01(\tR\fattachmentId\"\x12\n" +
+	"\x10AckDownloadReply2\x8a\x02\n" +
+	"\x11AttachmentService\x12J\n" +
+	"\x06Upload\x12\x1f.gonec.gateway.v1.UploadRequest\x1a\x1d.gonec.gateway.v1.UploadReply(\x01\x12P\n" +
+	"\bDownload\x12!.gonec.gateway.v1.DownloadRequest\x1a\x1f.gonec.gateway.v1.DownloadReply0\x01\x12W\n" +
+	"\vAckDownload\x12$.gonec.gateway.v1.AckDownloadRequest\x1a\".gonec.gateway.v1.AckDownloadReplyB)Z'github.com/charadev96/gonec/gen/gatewayb\x06proto3"
+
+var (
+	file_gateway_attachment_proto_rawDescOnce sync.Once
+	file_gateway_attachment_proto_rawDescData []byte
+)
+
+func file_gateway_attachment_proto_rawDescGZIP() []byte {
+	file_gateway_attachment_proto_rawDescOnce.Do(func() {
+		file_gateway_attachment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_gateway_attachment_proto_rawDesc), len(file_gateway_attachment_proto_rawDesc)))
+	})
+	return file_gateway_attachment_proto_rawDescData
+}
+
+var file_gateway_attachment_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_gateway_attachment_proto_goTypes = []any{
+	(*UploadRequest)(nil),      // 0: gonec.gateway.v1.UploadRequest
+	(*UploadOpen)(nil),         // 1: gonec.gateway.v1.UploadOpen
+	(*UploadReply)(nil),        // 2: gonec.gateway.v1.UploadReply
+	(*DownloadRequest)(nil),    // 3: gonec.gateway.v1.DownloadRequest
+	(*DownloadReply)(nil),      // 4: gonec.gateway.v1.DownloadReply
+	(*AckDownloadRequest)(nil), // 5: gonec.gateway.v1.AckDownloadRequest
+	(*AckDownloadReply)(nil),   // 6: gonec.gateway.v1.AckDownloadReply
+	(*shared.Session)(nil),     // 7: gonec.shared.v1.Session
+	(*shared.Attachment)(nil),  // 8: gonec.shared.v1.Attachment
+}
+var file_gateway_attachment_proto_depIdxs = []int32{
+	1, // 0: gonec.gateway.v1.UploadRequest.open:type_name -> gonec.gateway.v1.UploadOpen
+	7, // 1: gonec.gateway.v1.UploadOpen.auth:type_name -> gonec.shared.v1.Session
+	8, // 2: gonec.gateway.v1.UploadReply.attachment:type_name -> gonec.shared.v1.Attachment
+	7, // 3: gonec.gateway.v1.DownloadRequest.auth:type_name -> gonec.shared.v1.Session
+	8, // 4: gonec.gateway.v1.DownloadReply.attachment:type_name -> gonec.shared.v1.Attachment
+	7, // 5: gonec.gateway.v1.AckDownloadRequest.auth:type_name -> gonec.shared.v1.Session
+	0, // 6: gonec.gateway.v1.AttachmentService.Upload:input_type -> gonec.gateway.v1.UploadRequest
+	3, // 7: gonec.gateway.v1.AttachmentService.Download:input_type -> gonec.gateway.v1.DownloadRequest
+	5, // 8: gonec.gateway.v1.AttachmentService.AckDownload:input_type -> gonec.gateway.v1.AckDownloadRequest
+	2, // 9: gonec.gateway.v1.AttachmentService.Upload:output_type -> gonec.gateway.v1.UploadReply
+	4, // 10: gonec.gateway.v1.AttachmentService.Download:output_type -> gonec.gateway.v1.DownloadReply
+	6, // 11: gonec.gateway.v1.AttachmentService.AckDownload:output_type -> gonec.gateway.v1.AckDownloadReply
+	9, // [9:12] is the sub-list for method output_type
+	6, // [6:9] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_gateway_attachment_proto_init() }
+func file_gateway_attachment_proto_init() {
+	if File_gateway_attachment_proto != nil {
+		return
+	}
+	file_gateway_attachment_proto_msgTypes[0].OneofWrappers = []any{
+		(*UploadRequest_Open)(nil),
+		(*UploadRequest_Chunk)(nil),
+	}
+	file_gateway_attachment_proto_msgTypes[4].OneofWrappers = []any{
+		(*DownloadReply_Attachment)(nil),
+		(*DownloadReply_Chunk)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gateway_attachment_proto_rawDesc), len(file_gateway_attachment_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_gateway_attachment_proto_goTypes,
+		DependencyIndexes: file_gateway_attachment_proto_depIdxs,
+		MessageInfos:      file_gateway_attachment_proto_msgTypes,
+	}.Build()
+	File_gateway_attachment_proto = out.File
+	file_gateway_attachment_proto_goTypes = nil
+	file_gateway_attachment_proto_depIdxs = nil
+}
@@ -382,6 +382,86 @@ func (*LogoutReply) Descriptor() ([]byte, []int) {
 	return file_gateway_auth_proto_rawDescGZIP(), []int{7}
 }
 
+type GetKeyRolloverRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyRolloverRequest) Reset() {
+	*x = GetKeyRolloverRequest{}
+	mi := &file_gateway_auth_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyRolloverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyRolloverRequest) ProtoMessage() {}
+
+func (x *GetKeyRolloverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_auth_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyRolloverRequest.ProtoReflect.Descriptor instead.
+func (*GetKeyRolloverRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_auth_proto_rawDescGZIP(), []int{8}
+}
+
+type GetKeyRolloverReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rollover      *shared.KeyRollover    `protobuf:"bytes,1,opt,name=rollover,proto3" json:"rollover,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyRolloverReply) Reset() {
+	*x = GetKeyRolloverReply{}
+	mi := &file_gateway_auth_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyRolloverReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyRolloverReply) ProtoMessage() {}
+
+func (x *GetKeyRolloverReply) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_auth_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyRolloverReply.ProtoReflect.Descriptor instead.
+func (*GetKeyRolloverReply) Descriptor() ([]byte, []int) {
+	return file_gateway_auth_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetKeyRolloverReply) GetRollover() *shared.KeyRollover {
+	if x != nil {
+		return x.Rollover
+	}
+	return nil
+}
+
 var File_gateway_auth_proto protoreflect.FileDescriptor
 
 const file_gateway_auth_proto_rawDesc = "" +
@@ -404,12 +484,16 @@ const file_gateway_auth_proto_rawDesc = "" +
 	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\"=\n" +
 	"\rLogoutRequest\x12,\n" +
 	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\"\r\n" +
-	"\vLogoutReply2\xe5\x02\n" +
+	"\vLogoutReply\"\x17\n" +
+	"\x15GetKeyRolloverRequest\"O\n" +
+	"\x13GetKeyRolloverReply\x128\n" +
+	"\brollover\x18\x01 \x01(\v2\x1c.gonec.shared.v1.KeyRolloverR\brollover2\xc7\x03\n" +
 	"\vAuthService\x12N\n" +
 	"\bRegister\x12!.gonec.gateway.v1.RegisterRequest\x1a\x1f.gonec.gateway.v1.RegisterReply\x12]\n" +
 	"\rInitiateLogin\x12&.gonec.gateway.v1.InitiateLoginRequest\x1a$.gonec.gateway.v1.InitiateLoginReply\x12]\n" +
 	"\rCompleteLogin\x12&.gonec.gateway.v1.CompleteLoginRequest\x1a$.gonec.gateway.v1.CompleteLoginReply\x12H\n" +
-	"\x06Logout\x12\x1f.gonec.gateway.v1.LogoutRequest\x1a\x1d.gonec.gateway.v1.LogoutReplyB)Z'github.com/charadev96/gonec/gen/gatewayb\x06proto3"
+	"\x06Logout\x12\x1f.gonec.gateway.v1.LogoutRequest\x1a\x1d.gonec.gateway.v1.LogoutReply\x12`\n" +
+	"\x0eGetKeyRollover\x12'.gonec.gateway.v1.GetKeyRolloverRequest\x1a%.gonec.gateway.v1.GetKeyRolloverReplyB)Z'github.com/charadev96/gonec/gen/gatewayb\x06proto3"
 
 var (
 	file_gateway_auth_proto_rawDescOnce sync.Once
@@ -423,34 +507,40 @@ func file_gateway_auth_proto_rawDescGZIP() []byte {
 	return file_gateway_auth_proto_rawDescData
 }
 
-var file_gateway_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_gateway_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_gateway_auth_proto_goTypes = []any{
-	(*RegisterRequest)(nil),      // 0: gonec.gateway.v1.RegisterRequest
-	(*RegisterReply)(nil),        // 1: gonec.gateway.v1.RegisterReply
-	(*InitiateLoginRequest)(nil), // 2: gonec.gateway.v1.InitiateLoginRequest
-	(*InitiateLoginReply)(nil),   // 3: gonec.gateway.v1.InitiateLoginReply
-	(*CompleteLoginRequest)(nil), // 4: gonec.gateway.v1.CompleteLoginRequest
-	(*CompleteLoginReply)(nil),   // 5: gonec.gateway.v1.CompleteLoginReply
-	(*LogoutRequest)(nil),        // 6: gonec.gateway.v1.LogoutRequest
-	(*LogoutReply)(nil),          // 7: gonec.gateway.v1.LogoutReply
-	(*shared.Session)(nil),       // 8: gonec.shared.v1.Session
+	(*RegisterRequest)(nil),       // 0: gonec.gateway.v1.RegisterRequest
+	(*RegisterReply)(nil),         // 1: gonec.gateway.v1.RegisterReply
+	(*InitiateLoginRequest)(nil),  // 2: gonec.gateway.v1.InitiateLoginRequest
+	(*InitiateLoginReply)(nil),    // 3: gonec.gateway.v1.InitiateLoginReply
+	(*CompleteLoginRequest)(nil),  // 4: gonec.gateway.v1.CompleteLoginRequest
+	(*CompleteLoginReply)(nil),    // 5: gonec.gateway.v1.CompleteLoginReply
+	(*LogoutRequest)(nil),         // 6: gonec.gateway.v1.LogoutRequest
+	(*LogoutReply)(nil),           // 7: gonec.gateway.v1.LogoutReply
+	(*GetKeyRolloverRequest)(nil), // 8: gonec.gateway.v1.GetKeyRolloverRequest
+	(*GetKeyRolloverReply)(nil),   // 9: gonec.gateway.v1.GetKeyRolloverReply
+	(*shared.Session)(nil),        // 10: gonec.shared.v1.Session
+	(*shared.KeyRollover)(nil),    // 11: gonec.shared.v1.KeyRollover
 }
 var file_gateway_auth_proto_depIdxs = []int32{
-	8, // 0: gonec.gateway.v1.CompleteLoginReply.auth:type_name -> gonec.shared.v1.Session
-	8, // 1: gonec.gateway.v1.LogoutRequest.auth:type_name -> gonec.shared.v1.Session
-	0, // 2: gonec.gateway.v1.AuthService.Register:input_type -> gonec.gateway.v1.RegisterRequest
-	2, // 3: gonec.gateway.v1.AuthService.InitiateLogin:input_type -> gonec.gateway.v1.InitiateLoginRequest
-	4, // 4: gonec.gateway.v1.AuthService.CompleteLogin:input_type -> gonec.gateway.v1.CompleteLoginRequest
-	6, // 5: gonec.gateway.v1.AuthService.Logout:input_type -> gonec.gateway.v1.LogoutRequest
-	1, // 6: gonec.gateway.v1.AuthService.Register:output_type -> gonec.gateway.v1.RegisterReply
-	3, // 7: gonec.gateway.v1.AuthService.InitiateLogin:output_type -> gonec.gateway.v1.InitiateLoginReply
-	5, // 8: gonec.gateway.v1.AuthService.CompleteLogin:output_type -> gonec.gateway.v1.CompleteLoginReply
-	7, // 9: gonec.gateway.v1.AuthService.Logout:output_type -> gonec.gateway.v1.LogoutReply
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	10, // 0: gonec.gateway.v1.CompleteLoginReply.auth:type_name -> gonec.shared.v1.Session
+	10, // 1: gonec.gateway.v1.LogoutRequest.auth:type_name -> gonec.shared.v1.Session
+	11, // 2: gonec.gateway.v1.GetKeyRolloverReply.rollover:type_name -> gonec.shared.v1.KeyRollover
+	0,  // 3: gonec.gateway.v1.AuthService.Register:input_type -> gonec.gateway.v1.RegisterRequest
+	2,  // 4: gonec.gateway.v1.AuthService.InitiateLogin:input_type -> gonec.gateway.v1.InitiateLoginRequest
+	4,  // 5: gonec.gateway.v1.AuthService.CompleteLogin:input_type -> gonec.gateway.v1.CompleteLoginRequest
+	6,  // 6: gonec.gateway.v1.AuthService.Logout:input_type -> gonec.gateway.v1.LogoutRequest
+	8,  // 7: gonec.gateway.v1.AuthService.GetKeyRollover:input_type -> gonec.gateway.v1.GetKeyRolloverRequest
+	1,  // 8: gonec.gateway.v1.AuthService.Register:output_type -> gonec.gateway.v1.RegisterReply
+	3,  // 9: gonec.gateway.v1.AuthService.InitiateLogin:output_type -> gonec.gateway.v1.InitiateLoginReply
+	5,  // 10: gonec.gateway.v1.AuthService.CompleteLogin:output_type -> gonec.gateway.v1.CompleteLoginReply
+	7,  // 11: gonec.gateway.v1.AuthService.Logout:output_type -> gonec.gateway.v1.LogoutReply
+	9,  // 12: gonec.gateway.v1.AuthService.GetKeyRollover:output_type -> gonec.gateway.v1.GetKeyRolloverReply
+	8,  // [8:13] is the sub-list for method output_type
+	3,  // [3:8] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_gateway_auth_proto_init() }
@@ -464,7 +554,7 @@ func file_gateway_auth_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gateway_auth_proto_rawDesc), len(file_gateway_auth_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
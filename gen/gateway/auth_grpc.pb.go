@@ -19,10 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuthService_Register_FullMethodName      = "/gonec.gateway.v1.AuthService/Register"
-	AuthService_InitiateLogin_FullMethodName = "/gonec.gateway.v1.AuthService/InitiateLogin"
-	AuthService_CompleteLogin_FullMethodName = "/gonec.gateway.v1.AuthService/CompleteLogin"
-	AuthService_Logout_FullMethodName        = "/gonec.gateway.v1.AuthService/Logout"
+	AuthService_Register_FullMethodName       = "/gonec.gateway.v1.AuthService/Register"
+	AuthService_InitiateLogin_FullMethodName  = "/gonec.gateway.v1.AuthService/InitiateLogin"
+	AuthService_CompleteLogin_FullMethodName  = "/gonec.gateway.v1.AuthService/CompleteLogin"
+	AuthService_Logout_FullMethodName         = "/gonec.gateway.v1.AuthService/Logout"
+	AuthService_GetKeyRollover_FullMethodName = "/gonec.gateway.v1.AuthService/GetKeyRollover"
 )
 
 // AuthServiceClient is the client API for AuthService service.
@@ -33,6 +34,7 @@ type AuthServiceClient interface {
 	InitiateLogin(ctx context.Context, in *InitiateLoginRequest, opts ...grpc.CallOption) (*InitiateLoginReply, error)
 	CompleteLogin(ctx context.Context, in *CompleteLoginRequest, opts ...grpc.CallOption) (*CompleteLoginReply, error)
 	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutReply, error)
+	GetKeyRollover(ctx context.Context, in *GetKeyRolloverRequest, opts ...grpc.CallOption) (*GetKeyRolloverReply, error)
 }
 
 type authServiceClient struct {
@@ -83,6 +85,16 @@ func (c *authServiceClient) Logout(ctx context.Context, in *LogoutRequest, opts
 	return out, nil
 }
 
+func (c *authServiceClient) GetKeyRollover(ctx context.Context, in *GetKeyRolloverRequest, opts ...grpc.CallOption) (*GetKeyRolloverReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetKeyRolloverReply)
+	err := c.cc.Invoke(ctx, AuthService_GetKeyRollover_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServiceServer is the server API for AuthService service.
 // All implementations must embed UnimplementedAuthServiceServer
 // for forward compatibility.
@@ -91,6 +103,7 @@ type AuthServiceServer interface {
 	InitiateLogin(context.Context, *InitiateLoginRequest) (*InitiateLoginReply, error)
 	CompleteLogin(context.Context, *CompleteLoginRequest) (*CompleteLoginReply, error)
 	Logout(context.Context, *LogoutRequest) (*LogoutReply, error)
+	GetKeyRollover(context.Context, *GetKeyRolloverRequest) (*GetKeyRolloverReply, error)
 	mustEmbedUnimplementedAuthServiceServer()
 }
 
@@ -113,6 +126,9 @@ func (UnimplementedAuthServiceServer) CompleteLogin(context.Context, *CompleteLo
 func (UnimplementedAuthServiceServer) Logout(context.Context, *LogoutRequest) (*LogoutReply, error) {
 	return nil, status.Error(codes.Unimplemented, "method Logout not implemented")
 }
+func (UnimplementedAuthServiceServer) GetKeyRollover(context.Context, *GetKeyRolloverRequest) (*GetKeyRolloverReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetKeyRollover not implemented")
+}
 func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
 func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
 
@@ -206,6 +222,24 @@ func _AuthService_Logout_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_GetKeyRollover_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyRolloverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetKeyRollover(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetKeyRollover_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetKeyRollover(ctx, req.(*GetKeyRolloverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +263,10 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Logout",
 			Handler:    _AuthService_Logout_Handler,
 		},
+		{
+			MethodName: "GetKeyRollover",
+			Handler:    _AuthService_GetKeyRollover_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "gateway/auth.proto",
@@ -27,6 +27,8 @@ type SendRequest struct {
 	Auth          *shared.Session        `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
 	Recipient     string                 `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
 	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	AttachmentIds []string               `protobuf:"bytes,4,rep,name=attachment_ids,json=attachmentIds,proto3" json:"attachment_ids,omitempty"`
+	ReplyTo       string                 `protobuf:"bytes,5,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -82,27 +84,1243 @@ func (x *SendRequest) GetContent() string {
 	return ""
 }
 
+func (x *SendRequest) GetAttachmentIds() []string {
+	if x != nil {
+		return x.AttachmentIds
+	}
+	return nil
+}
+
+func (x *SendRequest) GetReplyTo() string {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return ""
+}
+
 type SendReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecipientId   string                 `protobuf:"bytes,1,opt,name=recipient_id,json=recipientId,proto3" json:"recipient_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ThreadId      string                 `protobuf:"bytes,3,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendReply) Reset() {
+	*x = SendReply{}
+	mi := &file_gateway_chat_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendReply) ProtoMessage() {}
+
+func (x *SendReply) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendReply.ProtoReflect.Descriptor instead.
+func (*SendReply) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SendReply) GetRecipientId() string {
+	if x != nil {
+		return x.RecipientId
+	}
+	return ""
+}
+
+func (x *SendReply) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *SendReply) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+type ListenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Auth          *shared.Session        `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListenRequest) Reset() {
+	*x = ListenRequest{}
+	mi := &file_gateway_chat_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListenRequest) ProtoMessage() {}
+
+func (x *ListenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListenRequest.ProtoReflect.Descriptor instead.
+func (*ListenRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListenRequest) GetAuth() *shared.Session {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+type EventsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Auth            *shared.Session        `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	PresenceUserIds []string               `protobuf:"bytes,2,rep,name=presence_user_ids,json=presenceUserIds,proto3" json:"presence_user_ids,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EventsRequest) Reset() {
+	*x = EventsRequest{}
+	mi := &file_gateway_chat_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventsRequest) ProtoMessage() {}
+
+func (x *EventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventsRequest.ProtoReflect.Descriptor instead.
+func (*EventsRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EventsRequest) GetAuth() *shared.Session {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *EventsRequest) GetPresenceUserIds() []string {
+	if x != nil {
+		return x.PresenceUserIds
+	}
+	return nil
+}
+
+type ChatRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ChatRequest_Open
+	//	*ChatRequest_Send
+	Payload       isChatRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatRequest) Reset() {
+	*x = ChatRequest{}
+	mi := &file_gateway_chat_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatRequest) ProtoMessage() {}
+
+func (x *ChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatRequest.ProtoReflect.Descriptor instead.
+func (*ChatRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ChatRequest) GetPayload() isChatRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ChatRequest) GetOpen() *ChatOpen {
+	if x != nil {
+		if x, ok := x.Payload.(*ChatRequest_Open); ok {
+			return x.Open
+		}
+	}
+	return nil
+}
+
+func (x *ChatRequest) GetSend() *ChatSend {
+	if x != nil {
+		if x, ok := x.Payload.(*ChatRequest_Send); ok {
+			return x.Send
+		}
+	}
+	return nil
+}
+
+type isChatRequest_Payload interface {
+	isChatRequest_Payload()
+}
+
+type ChatRequest_Open struct {
+	Open *ChatOpen `protobuf:"bytes,1,opt,name=open,proto3,oneof"`
+}
+
+type ChatRequest_Send struct {
+	Send *ChatSend `protobuf:"bytes,2,opt,name=send,proto3,oneof"`
+}
+
+func (*ChatRequest_Open) isChatRequest_Payload() {}
+
+func (*ChatRequest_Send) isChatRequest_Payload() {}
+
+type ChatOpen struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Auth            *shared.Session        `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	PresenceUserIds []string               `protobuf:"bytes,2,rep,name=presence_user_ids,json=presenceUserIds,proto3" json:"presence_user_ids,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ChatOpen) Reset() {
+	*x = ChatOpen{}
+	mi := &file_gateway_chat_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatOpen) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatOpen) ProtoMessage() {}
+
+func (x *ChatOpen) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatOpen.ProtoReflect.Descriptor instead.
+func (*ChatOpen) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ChatOpen) GetAuth() *shared.Session {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *ChatOpen) GetPresenceUserIds() []string {
+	if x != nil {
+		return x.PresenceUserIds
+	}
+	return nil
+}
+
+type ChatSend struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Recipient     string                 `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	AttachmentIds []string               `protobuf:"bytes,4,rep,name=attachment_ids,json=attachmentIds,proto3" json:"attachment_ids,omitempty"`
+	ReplyTo       string                 `protobuf:"bytes,5,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatSend) Reset() {
+	*x = ChatSend{}
+	mi := &file_gateway_chat_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatSend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatSend) ProtoMessage() {}
+
+func (x *ChatSend) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatSend.ProtoReflect.Descriptor instead.
+func (*ChatSend) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ChatSend) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ChatSend) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *ChatSend) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ChatSend) GetAttachmentIds() []string {
+	if x != nil {
+		return x.AttachmentIds
+	}
+	return nil
+}
+
+func (x *ChatSend) GetReplyTo() string {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return ""
+}
+
+type ChatReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ChatReply_Event
+	//	*ChatReply_Ack
+	Payload       isChatReply_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatReply) Reset() {
+	*x = ChatReply{}
+	mi := &file_gateway_chat_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatReply) ProtoMessage() {}
+
+func (x *ChatReply) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatReply.ProtoReflect.Descriptor instead.
+func (*ChatReply) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ChatReply) GetPayload() isChatReply_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ChatReply) GetEvent() *shared.Event {
+	if x != nil {
+		if x, ok := x.Payload.(*ChatReply_Event); ok {
+			return x.Event
+		}
+	}
+	return nil
+}
+
+func (x *ChatReply) GetAck() *ChatAck {
+	if x != nil {
+		if x, ok := x.Payload.(*ChatReply_Ack); ok {
+			return x.Ack
+		}
+	}
+	return nil
+}
+
+type isChatReply_Payload interface {
+	isChatReply_Payload()
+}
+
+type ChatReply_Event struct {
+	Event *shared.Event `protobuf:"bytes,1,opt,name=event,proto3,oneof"`
+}
+
+type ChatReply_Ack struct {
+	Ack *ChatAck `protobuf:"bytes,2,opt,name=ack,proto3,oneof"`
+}
+
+func (*ChatReply_Event) isChatReply_Payload() {}
+
+func (*ChatReply_Ack) isChatReply_Payload() {}
+
+type ChatAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Message       *shared.Message        `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Code          uint32                 `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatAck) Reset() {
+	*x = ChatAck{}
+	mi := &file_gateway_chat_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatAck) ProtoMessage() {}
+
+func (x *ChatAck) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatAck.ProtoReflect.Descriptor instead.
+func (*ChatAck) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ChatAck) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ChatAck) GetMessage() *shared.Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ChatAck) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ChatAck) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ChatAck) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type EditMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Auth          *shared.Session        `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditMessageRequest) Reset() {
+	*x = EditMessageRequest{}
+	mi := &file_gateway_chat_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMessageRequest) ProtoMessage() {}
+
+func (x *EditMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMessageRequest.ProtoReflect.Descriptor instead.
+func (*EditMessageRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EditMessageRequest) GetAuth() *shared.Session {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *EditMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type EditMessageReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Edit          *shared.MessageEdit    `protobuf:"bytes,1,opt,name=edit,proto3" json:"edit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditMessageReply) Reset() {
+	*x = EditMessageReply{}
+	mi := &file_gateway_chat_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMessageReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMessageReply) ProtoMessage() {}
+
+func (x *EditMessageReply) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMessageReply.ProtoReflect.Descriptor instead.
+func (*EditMessageReply) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EditMessageReply) GetEdit() *shared.MessageEdit {
+	if x != nil {
+		return x.Edit
+	}
+	return nil
+}
+
+type DeleteMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Auth          *shared.Session        `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMessageRequest) Reset() {
+	*x = DeleteMessageRequest{}
+	mi := &file_gateway_chat_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMessageRequest) ProtoMessage() {}
+
+func (x *DeleteMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMessageRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMessageRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteMessageRequest) GetAuth() *shared.Session {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *DeleteMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type DeleteMessageReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delete        *shared.MessageDelete  `protobuf:"bytes,1,opt,name=delete,proto3" json:"delete,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMessageReply) Reset() {
+	*x = DeleteMessageReply{}
+	mi := &file_gateway_chat_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMessageReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMessageReply) ProtoMessage() {}
+
+func (x *DeleteMessageReply) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMessageReply.ProtoReflect.Descriptor instead.
+func (*DeleteMessageReply) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteMessageReply) GetDelete() *shared.MessageDelete {
+	if x != nil {
+		return x.Delete
+	}
+	return nil
+}
+
+type ReactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Auth          *shared.Session        `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Emoji         string                 `protobuf:"bytes,3,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	Remove        bool                   `protobuf:"varint,4,opt,name=remove,proto3" json:"remove,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactRequest) Reset() {
+	*x = ReactRequest{}
+	mi := &file_gateway_chat_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactRequest) ProtoMessage() {}
+
+func (x *ReactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactRequest.ProtoReflect.Descriptor instead.
+func (*ReactRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReactRequest) GetAuth() *shared.Session {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *ReactRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ReactRequest) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *ReactRequest) GetRemove() bool {
+	if x != nil {
+		return x.Remove
+	}
+	return false
+}
+
+type ReactReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reaction      *shared.Reaction       `protobuf:"bytes,1,opt,name=reaction,proto3" json:"reaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactReply) Reset() {
+	*x = ReactReply{}
+	mi := &file_gateway_chat_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactReply) ProtoMessage() {}
+
+func (x *ReactReply) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactReply.ProtoReflect.Descriptor instead.
+func (*ReactReply) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReactReply) GetReaction() *shared.Reaction {
+	if x != nil {
+		return x.Reaction
+	}
+	return nil
+}
+
+type ListThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Auth          *shared.Session        `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Limit         uint32                 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListThreadRequest) Reset() {
+	*x = ListThreadRequest{}
+	mi := &file_gateway_chat_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListThreadRequest) ProtoMessage() {}
+
+func (x *ListThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListThreadRequest.ProtoReflect.Descriptor instead.
+func (*ListThreadRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListThreadRequest) GetAuth() *shared.Session {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *ListThreadRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ListThreadRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListThreadRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListThreadReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*shared.Message      `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListThreadReply) Reset() {
+	*x = ListThreadReply{}
+	mi := &file_gateway_chat_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListThreadReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListThreadReply) ProtoMessage() {}
+
+func (x *ListThreadReply) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListThreadReply.ProtoReflect.Descriptor instead.
+func (*ListThreadReply) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListThreadReply) GetMessages() []*shared.Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *ListThreadReply) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type LookupUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Auth          *shared.Session        `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	User          string                 `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupUserRequest) Reset() {
+	*x = LookupUserRequest{}
+	mi := &file_gateway_chat_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupUserRequest) ProtoMessage() {}
+
+func (x *LookupUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupUserRequest.ProtoReflect.Descriptor instead.
+func (*LookupUserRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *LookupUserRequest) GetAuth() *shared.Session {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *LookupUserRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+type LookupUserReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *shared.UserIdentity   `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupUserReply) Reset() {
+	*x = LookupUserReply{}
+	mi := &file_gateway_chat_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupUserReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupUserReply) ProtoMessage() {}
+
+func (x *LookupUserReply) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupUserReply.ProtoReflect.Descriptor instead.
+func (*LookupUserReply) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *LookupUserReply) GetUser() *shared.UserIdentity {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type GetPresenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Auth          *shared.Session        `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	UserIds       []string               `protobuf:"bytes,2,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPresenceRequest) Reset() {
+	*x = GetPresenceRequest{}
+	mi := &file_gateway_chat_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPresenceRequest) ProtoMessage() {}
+
+func (x *GetPresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPresenceRequest.ProtoReflect.Descriptor instead.
+func (*GetPresenceRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetPresenceRequest) GetAuth() *shared.Session {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *GetPresenceRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type GetPresenceReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Presences     []*shared.Presence     `protobuf:"bytes,1,rep,name=presences,proto3" json:"presences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPresenceReply) Reset() {
+	*x = GetPresenceReply{}
+	mi := &file_gateway_chat_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPresenceReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPresenceReply) ProtoMessage() {}
+
+func (x *GetPresenceReply) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPresenceReply.ProtoReflect.Descriptor instead.
+func (*GetPresenceReply) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetPresenceReply) GetPresences() []*shared.Presence {
+	if x != nil {
+		return x.Presences
+	}
+	return nil
+}
+
+type WatchPresenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Auth          *shared.Session        `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	UserIds       []string               `protobuf:"bytes,2,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchPresenceRequest) Reset() {
+	*x = WatchPresenceRequest{}
+	mi := &file_gateway_chat_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPresenceRequest) ProtoMessage() {}
+
+func (x *WatchPresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPresenceRequest.ProtoReflect.Descriptor instead.
+func (*WatchPresenceRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WatchPresenceRequest) GetAuth() *shared.Session {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *WatchPresenceRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type SetPresenceHiddenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Auth          *shared.Session        `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	Hidden        bool                   `protobuf:"varint,2,opt,name=hidden,proto3" json:"hidden,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SendReply) Reset() {
-	*x = SendReply{}
-	mi := &file_gateway_chat_proto_msgTypes[1]
+func (x *SetPresenceHiddenRequest) Reset() {
+	*x = SetPresenceHiddenRequest{}
+	mi := &file_gateway_chat_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendReply) String() string {
+func (x *SetPresenceHiddenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendReply) ProtoMessage() {}
+func (*SetPresenceHiddenRequest) ProtoMessage() {}
 
-func (x *SendReply) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_chat_proto_msgTypes[1]
+func (x *SetPresenceHiddenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -113,33 +1331,84 @@ func (x *SendReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendReply.ProtoReflect.Descriptor instead.
-func (*SendReply) Descriptor() ([]byte, []int) {
-	return file_gateway_chat_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use SetPresenceHiddenRequest.ProtoReflect.Descriptor instead.
+func (*SetPresenceHiddenRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{22}
 }
 
-type ListenRequest struct {
+func (x *SetPresenceHiddenRequest) GetAuth() *shared.Session {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *SetPresenceHiddenRequest) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
+type SetPresenceHiddenReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPresenceHiddenReply) Reset() {
+	*x = SetPresenceHiddenReply{}
+	mi := &file_gateway_chat_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPresenceHiddenReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPresenceHiddenReply) ProtoMessage() {}
+
+func (x *SetPresenceHiddenReply) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPresenceHiddenReply.ProtoReflect.Descriptor instead.
+func (*SetPresenceHiddenReply) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{23}
+}
+
+type BlockUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Auth          *shared.Session        `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	User          string                 `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListenRequest) Reset() {
-	*x = ListenRequest{}
-	mi := &file_gateway_chat_proto_msgTypes[2]
+func (x *BlockUserRequest) Reset() {
+	*x = BlockUserRequest{}
+	mi := &file_gateway_chat_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListenRequest) String() string {
+func (x *BlockUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListenRequest) ProtoMessage() {}
+func (*BlockUserRequest) ProtoMessage() {}
 
-func (x *ListenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_chat_proto_msgTypes[2]
+func (x *BlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -150,33 +1419,369 @@ func (x *ListenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListenRequest.ProtoReflect.Descriptor instead.
-func (*ListenRequest) Descriptor() ([]byte, []int) {
-	return file_gateway_chat_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use BlockUserRequest.ProtoReflect.Descriptor instead.
+func (*BlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *ListenRequest) GetAuth() *shared.Session {
+func (x *BlockUserRequest) GetAuth() *shared.Session {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *BlockUserRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+type BlockUserReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Block         *shared.Block          `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserReply) Reset() {
+	*x = BlockUserReply{}
+	mi := &file_gateway_chat_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserReply) ProtoMessage() {}
+
+func (x *BlockUserReply) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserReply.ProtoReflect.Descriptor instead.
+func (*BlockUserReply) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *BlockUserReply) GetBlock() *shared.Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+type UnblockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Auth          *shared.Session        `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserRequest) Reset() {
+	*x = UnblockUserRequest{}
+	mi := &file_gateway_chat_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserRequest) ProtoMessage() {}
+
+func (x *UnblockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserRequest.ProtoReflect.Descriptor instead.
+func (*UnblockUserRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UnblockUserRequest) GetAuth() *shared.Session {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *UnblockUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UnblockUserReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserReply) Reset() {
+	*x = UnblockUserReply{}
+	mi := &file_gateway_chat_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserReply) ProtoMessage() {}
+
+func (x *UnblockUserReply) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserReply.ProtoReflect.Descriptor instead.
+func (*UnblockUserReply) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{27}
+}
+
+type ListBlocksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Auth          *shared.Session        `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlocksRequest) Reset() {
+	*x = ListBlocksRequest{}
+	mi := &file_gateway_chat_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlocksRequest) ProtoMessage() {}
+
+func (x *ListBlocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlocksRequest.ProtoReflect.Descriptor instead.
+func (*ListBlocksRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListBlocksRequest) GetAuth() *shared.Session {
 	if x != nil {
 		return x.Auth
 	}
 	return nil
 }
 
+type ListBlocksReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blocks        []*shared.Block        `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlocksReply) Reset() {
+	*x = ListBlocksReply{}
+	mi := &file_gateway_chat_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlocksReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlocksReply) ProtoMessage() {}
+
+func (x *ListBlocksReply) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_chat_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlocksReply.ProtoReflect.Descriptor instead.
+func (*ListBlocksReply) Descriptor() ([]byte, []int) {
+	return file_gateway_chat_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListBlocksReply) GetBlocks() []*shared.Block {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
 var File_gateway_chat_proto protoreflect.FileDescriptor
 
 const file_gateway_chat_proto_rawDesc = "" +
 	"\n" +
-	"\x12gateway/chat.proto\x12\x10gonec.gateway.v1\x1a\x11shared/auth.proto\x1a\x11shared/chat.proto\"s\n" +
+	"\x12gateway/chat.proto\x12\x10gonec.gateway.v1\x1a\x11shared/auth.proto\x1a\x11shared/chat.proto\"\xb5\x01\n" +
 	"\vSendRequest\x12,\n" +
 	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\x12\x1c\n" +
 	"\trecipient\x18\x02 \x01(\tR\trecipient\x12\x18\n" +
-	"\acontent\x18\x03 \x01(\tR\acontent\"\v\n" +
-	"\tSendReply\"=\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12%\n" +
+	"\x0eattachment_ids\x18\x04 \x03(\tR\rattachmentIds\x12\x19\n" +
+	"\breply_to\x18\x05 \x01(\tR\areplyTo\"j\n" +
+	"\tSendReply\x12!\n" +
+	"\frecipient_id\x18\x01 \x01(\tR\vrecipientId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x1b\n" +
+	"\tthread_id\x18\x03 \x01(\tR\bthreadId\"=\n" +
 	"\rListenRequest\x12,\n" +
-	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth2\x98\x01\n" +
+	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\"i\n" +
+	"\rEventsRequest\x12,\n" +
+	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\x12*\n" +
+	"\x11presence_user_ids\x18\x02 \x03(\tR\x0fpresenceUserIds\"|\n" +
+	"\vChatRequest\x120\n" +
+	"\x04open\x18\x01 \x01(\v2\x1a.gonec.gateway.v1.ChatOpenH\x00R\x04open\x120\n" +
+	"\x04send\x18\x02 \x01(\v2\x1a.gonec.gateway.v1.ChatSendH\x00R\x04sendB\t\n" +
+	"\apayload\"d\n" +
+	"\bChatOpen\x12,\n" +
+	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\x12*\n" +
+	"\x11presence_user_ids\x18\x02 \x03(\tR\x0fpresenceUserIds\"\x94\x01\n" +
+	"\bChatSend\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1c\n" +
+	"\trecipient\x18\x02 \x01(\tR\trecipient\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12%\n" +
+	"\x0eattachment_ids\x18\x04 \x03(\tR\rattachmentIds\x12\x19\n" +
+	"\breply_to\x18\x05 \x01(\tR\areplyTo\"u\n" +
+	"\tChatReply\x12.\n" +
+	"\x05event\x18\x01 \x01(\v2\x16.gonec.shared.v1.EventH\x00R\x05event\x12-\n" +
+	"\x03ack\x18\x02 \x01(\v2\x19.gonec.gateway.v1.ChatAckH\x00R\x03ackB\t\n" +
+	"\apayload\"\x8f\x01\n" +
+	"\aChatAck\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x122\n" +
+	"\amessage\x18\x02 \x01(\v2\x18.gonec.shared.v1.MessageR\amessage\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\rR\x04code\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"{\n" +
+	"\x12EditMessageRequest\x12,\n" +
+	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\"D\n" +
+	"\x10EditMessageReply\x120\n" +
+	"\x04edit\x18\x01 \x01(\v2\x1c.gonec.shared.v1.MessageEditR\x04edit\"c\n" +
+	"\x14DeleteMessageRequest\x12,\n" +
+	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\"L\n" +
+	"\x12DeleteMessageReply\x126\n" +
+	"\x06delete\x18\x01 \x01(\v2\x1e.gonec.shared.v1.MessageDeleteR\x06delete\"\x89\x01\n" +
+	"\fReactRequest\x12,\n" +
+	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x14\n" +
+	"\x05emoji\x18\x03 \x01(\tR\x05emoji\x12\x16\n" +
+	"\x06remove\x18\x04 \x01(\bR\x06remove\"C\n" +
+	"\n" +
+	"ReactReply\x125\n" +
+	"\breaction\x18\x01 \x01(\v2\x19.gonec.shared.v1.ReactionR\breaction\"\x8e\x01\n" +
+	"\x11ListThreadRequest\x12,\n" +
+	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\rR\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"_\n" +
+	"\x0fListThreadReply\x124\n" +
+	"\bmessages\x18\x01 \x03(\v2\x18.gonec.shared.v1.MessageR\bmessages\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\"U\n" +
+	"\x11LookupUserRequest\x12,\n" +
+	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\x12\x12\n" +
+	"\x04user\x18\x02 \x01(\tR\x04user\"D\n" +
+	"\x0fLookupUserReply\x121\n" +
+	"\x04user\x18\x01 \x01(\v2\x1d.gonec.shared.v1.UserIdentityR\x04user\"]\n" +
+	"\x12GetPresenceRequest\x12,\n" +
+	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\tR\auserIds\"K\n" +
+	"\x10GetPresenceReply\x127\n" +
+	"\tpresences\x18\x01 \x03(\v2\x19.gonec.shared.v1.PresenceR\tpresences\"_\n" +
+	"\x14WatchPresenceRequest\x12,\n" +
+	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\tR\auserIds\"`\n" +
+	"\x18SetPresenceHiddenRequest\x12,\n" +
+	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\x12\x16\n" +
+	"\x06hidden\x18\x02 \x01(\bR\x06hidden\"\x18\n" +
+	"\x16SetPresenceHiddenReply\"T\n" +
+	"\x10BlockUserRequest\x12,\n" +
+	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\x12\x12\n" +
+	"\x04user\x18\x02 \x01(\tR\x04user\">\n" +
+	"\x0eBlockUserReply\x12,\n" +
+	"\x05block\x18\x01 \x01(\v2\x16.gonec.shared.v1.BlockR\x05block\"[\n" +
+	"\x12UnblockUserRequest\x12,\n" +
+	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x12\n" +
+	"\x10UnblockUserReply\"A\n" +
+	"\x11ListBlocksRequest\x12,\n" +
+	"\x04auth\x18\x01 \x01(\v2\x18.gonec.shared.v1.SessionR\x04auth\"A\n" +
+	"\x0fListBlocksReply\x12.\n" +
+	"\x06blocks\x18\x01 \x03(\v2\x16.gonec.shared.v1.BlockR\x06blocks2\xec\t\n" +
 	"\vChatService\x12B\n" +
 	"\x04Send\x12\x1d.gonec.gateway.v1.SendRequest\x1a\x1b.gonec.gateway.v1.SendReply\x12E\n" +
-	"\x06Listen\x12\x1f.gonec.gateway.v1.ListenRequest\x1a\x18.gonec.shared.v1.Message0\x01B)Z'github.com/charadev96/gonec/gen/gatewayb\x06proto3"
+	"\x06Listen\x12\x1f.gonec.gateway.v1.ListenRequest\x1a\x18.gonec.shared.v1.Message0\x01\x12C\n" +
+	"\x06Events\x12\x1f.gonec.gateway.v1.EventsRequest\x1a\x16.gonec.shared.v1.Event0\x01\x12F\n" +
+	"\x04Chat\x12\x1d.gonec.gateway.v1.ChatRequest\x1a\x1b.gonec.gateway.v1.ChatReply(\x010\x01\x12W\n" +
+	"\vEditMessage\x12$.gonec.gateway.v1.EditMessageRequest\x1a\".gonec.gateway.v1.EditMessageReply\x12]\n" +
+	"\rDeleteMessage\x12&.gonec.gateway.v1.DeleteMessageRequest\x1a$.gonec.gateway.v1.DeleteMessageReply\x12E\n" +
+	"\x05React\x12\x1e.gonec.gateway.v1.ReactRequest\x1a\x1c.gonec.gateway.v1.ReactReply\x12T\n" +
+	"\n" +
+	"ListThread\x12#.gonec.gateway.v1.ListThreadRequest\x1a!.gonec.gateway.v1.ListThreadReply\x12T\n" +
+	"\n" +
+	"LookupUser\x12#.gonec.gateway.v1.LookupUserRequest\x1a!.gonec.gateway.v1.LookupUserReply\x12W\n" +
+	"\vGetPresence\x12$.gonec.gateway.v1.GetPresenceRequest\x1a\".gonec.gateway.v1.GetPresenceReply\x12T\n" +
+	"\rWatchPresence\x12&.gonec.gateway.v1.WatchPresenceRequest\x1a\x19.gonec.shared.v1.Presence0\x01\x12i\n" +
+	"\x11SetPresenceHidden\x12*.gonec.gateway.v1.SetPresenceHiddenRequest\x1a(.gonec.gateway.v1.SetPresenceHiddenReply\x12Q\n" +
+	"\tBlockUser\x12\".gonec.gateway.v1.BlockUserRequest\x1a .gonec.gateway.v1.BlockUserReply\x12W\n" +
+	"\vUnblockUser\x12$.gonec.gateway.v1.UnblockUserRequest\x1a\".gonec.gateway.v1.UnblockUserReply\x12T\n" +
+	"\n" +
+	"ListBlocks\x12#.gonec.gateway.v1.ListBlocksRequest\x1a!.gonec.gateway.v1.ListBlocksReplyB)Z'github.com/charadev96/gonec/gen/gatewayb\x06proto3"
 
 var (
 	file_gateway_chat_proto_rawDescOnce sync.Once
@@ -190,26 +1795,112 @@ func file_gateway_chat_proto_rawDescGZIP() []byte {
 	return file_gateway_chat_proto_rawDescData
 }
 
-var file_gateway_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_gateway_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_gateway_chat_proto_goTypes = []any{
-	(*SendRequest)(nil),    // 0: gonec.gateway.v1.SendRequest
-	(*SendReply)(nil),      // 1: gonec.gateway.v1.SendReply
-	(*ListenRequest)(nil),  // 2: gonec.gateway.v1.ListenRequest
-	(*shared.Session)(nil), // 3: gonec.shared.v1.Session
-	(*shared.Message)(nil), // 4: gonec.shared.v1.Message
+	(*SendRequest)(nil),              // 0: gonec.gateway.v1.SendRequest
+	(*SendReply)(nil),                // 1: gonec.gateway.v1.SendReply
+	(*ListenRequest)(nil),            // 2: gonec.gateway.v1.ListenRequest
+	(*EventsRequest)(nil),            // 3: gonec.gateway.v1.EventsRequest
+	(*ChatRequest)(nil),              // 4: gonec.gateway.v1.ChatRequest
+	(*ChatOpen)(nil),                 // 5: gonec.gateway.v1.ChatOpen
+	(*ChatSend)(nil),                 // 6: gonec.gateway.v1.ChatSend
+	(*ChatReply)(nil),                // 7: gonec.gateway.v1.ChatReply
+	(*ChatAck)(nil),                  // 8: gonec.gateway.v1.ChatAck
+	(*EditMessageRequest)(nil),       // 9: gonec.gateway.v1.EditMessageRequest
+	(*EditMessageReply)(nil),         // 10: gonec.gateway.v1.EditMessageReply
+	(*DeleteMessageRequest)(nil),     // 11: gonec.gateway.v1.DeleteMessageRequest
+	(*DeleteMessageReply)(nil),       // 12: gonec.gateway.v1.DeleteMessageReply
+	(*ReactRequest)(nil),             // 13: gonec.gateway.v1.ReactRequest
+	(*ReactReply)(nil),               // 14: gonec.gateway.v1.ReactReply
+	(*ListThreadRequest)(nil),        // 15: gonec.gateway.v1.ListThreadRequest
+	(*ListThreadReply)(nil),          // 16: gonec.gateway.v1.ListThreadReply
+	(*LookupUserRequest)(nil),        // 17: gonec.gateway.v1.LookupUserRequest
+	(*LookupUserReply)(nil),          // 18: gonec.gateway.v1.LookupUserReply
+	(*GetPresenceRequest)(nil),       // 19: gonec.gateway.v1.GetPresenceRequest
+	(*GetPresenceReply)(nil),         // 20: gonec.gateway.v1.GetPresenceReply
+	(*WatchPresenceRequest)(nil),     // 21: gonec.gateway.v1.WatchPresenceRequest
+	(*SetPresenceHiddenRequest)(nil), // 22: gonec.gateway.v1.SetPresenceHiddenRequest
+	(*SetPresenceHiddenReply)(nil),   // 23: gonec.gateway.v1.SetPresenceHiddenReply
+	(*BlockUserRequest)(nil),         // 24: gonec.gateway.v1.BlockUserRequest
+	(*BlockUserReply)(nil),           // 25: gonec.gateway.v1.BlockUserReply
+	(*UnblockUserRequest)(nil),       // 26: gonec.gateway.v1.UnblockUserRequest
+	(*UnblockUserReply)(nil),         // 27: gonec.gateway.v1.UnblockUserReply
+	(*ListBlocksRequest)(nil),        // 28: gonec.gateway.v1.ListBlocksRequest
+	(*ListBlocksReply)(nil),          // 29: gonec.gateway.v1.ListBlocksReply
+	(*shared.Session)(nil),           // 30: gonec.shared.v1.Session
+	(*shared.Event)(nil),             // 31: gonec.shared.v1.Event
+	(*shared.Message)(nil),           // 32: gonec.shared.v1.Message
+	(*shared.MessageEdit)(nil),       // 33: gonec.shared.v1.MessageEdit
+	(*shared.MessageDelete)(nil),     // 34: gonec.shared.v1.MessageDelete
+	(*shared.Reaction)(nil),          // 35: gonec.shared.v1.Reaction
+	(*shared.UserIdentity)(nil),      // 36: gonec.shared.v1.UserIdentity
+	(*shared.Presence)(nil),          // 37: gonec.shared.v1.Presence
+	(*shared.Block)(nil),             // 38: gonec.shared.v1.Block
 }
 var file_gateway_chat_proto_depIdxs = []int32{
-	3, // 0: gonec.gateway.v1.SendRequest.auth:type_name -> gonec.shared.v1.Session
-	3, // 1: gonec.gateway.v1.ListenRequest.auth:type_name -> gonec.shared.v1.Session
-	0, // 2: gonec.gateway.v1.ChatService.Send:input_type -> gonec.gateway.v1.SendRequest
-	2, // 3: gonec.gateway.v1.ChatService.Listen:input_type -> gonec.gateway.v1.ListenRequest
-	1, // 4: gonec.gateway.v1.ChatService.Send:output_type -> gonec.gateway.v1.SendReply
-	4, // 5: gonec.gateway.v1.ChatService.Listen:output_type -> gonec.shared.v1.Message
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	30, // 0: gonec.gateway.v1.SendRequest.auth:type_name -> gonec.shared.v1.Session
+	30, // 1: gonec.gateway.v1.ListenRequest.auth:type_name -> gonec.shared.v1.Session
+	30, // 2: gonec.gateway.v1.EventsRequest.auth:type_name -> gonec.shared.v1.Session
+	5,  // 3: gonec.gateway.v1.ChatRequest.open:type_name -> gonec.gateway.v1.ChatOpen
+	6,  // 4: gonec.gateway.v1.ChatRequest.send:type_name -> gonec.gateway.v1.ChatSend
+	30, // 5: gonec.gateway.v1.ChatOpen.auth:type_name -> gonec.shared.v1.Session
+	31, // 6: gonec.gateway.v1.ChatReply.event:type_name -> gonec.shared.v1.Event
+	8,  // 7: gonec.gateway.v1.ChatReply.ack:type_name -> gonec.gateway.v1.ChatAck
+	32, // 8: gonec.gateway.v1.ChatAck.message:type_name -> gonec.shared.v1.Message
+	30, // 9: gonec.gateway.v1.EditMessageRequest.auth:type_name -> gonec.shared.v1.Session
+	33, // 10: gonec.gateway.v1.EditMessageReply.edit:type_name -> gonec.shared.v1.MessageEdit
+	30, // 11: gonec.gateway.v1.DeleteMessageRequest.auth:type_name -> gonec.shared.v1.Session
+	34, // 12: gonec.gateway.v1.DeleteMessageReply.delete:type_name -> gonec.shared.v1.MessageDelete
+	30, // 13: gonec.gateway.v1.ReactRequest.auth:type_name -> gonec.shared.v1.Session
+	35, // 14: gonec.gateway.v1.ReactReply.reaction:type_name -> gonec.shared.v1.Reaction
+	30, // 15: gonec.gateway.v1.ListThreadRequest.auth:type_name -> gonec.shared.v1.Session
+	32, // 16: gonec.gateway.v1.ListThreadReply.messages:type_name -> gonec.shared.v1.Message
+	30, // 17: gonec.gateway.v1.LookupUserRequest.auth:type_name -> gonec.shared.v1.Session
+	36, // 18: gonec.gateway.v1.LookupUserReply.user:type_name -> gonec.shared.v1.UserIdentity
+	30, // 19: gonec.gateway.v1.GetPresenceRequest.auth:type_name -> gonec.shared.v1.Session
+	37, // 20: gonec.gateway.v1.GetPresenceReply.presences:type_name -> gonec.shared.v1.Presence
+	30, // 21: gonec.gateway.v1.WatchPresenceRequest.auth:type_name -> gonec.shared.v1.Session
+	30, // 22: gonec.gateway.v1.SetPresenceHiddenRequest.auth:type_name -> gonec.shared.v1.Session
+	30, // 23: gonec.gateway.v1.BlockUserRequest.auth:type_name -> gonec.shared.v1.Session
+	38, // 24: gonec.gateway.v1.BlockUserReply.block:type_name -> gonec.shared.v1.Block
+	30, // 25: gonec.gateway.v1.UnblockUserRequest.auth:type_name -> gonec.shared.v1.Session
+	30, // 26: gonec.gateway.v1.ListBlocksRequest.auth:type_name -> gonec.shared.v1.Session
+	38, // 27: gonec.gateway.v1.ListBlocksReply.blocks:type_name -> gonec.shared.v1.Block
+	0,  // 28: gonec.gateway.v1.ChatService.Send:input_type -> gonec.gateway.v1.SendRequest
+	2,  // 29: gonec.gateway.v1.ChatService.Listen:input_type -> gonec.gateway.v1.ListenRequest
+	3,  // 30: gonec.gateway.v1.ChatService.Events:input_type -> gonec.gateway.v1.EventsRequest
+	4,  // 31: gonec.gateway.v1.ChatService.Chat:input_type -> gonec.gateway.v1.ChatRequest
+	9,  // 32: gonec.gateway.v1.ChatService.EditMessage:input_type -> gonec.gateway.v1.EditMessageRequest
+	11, // 33: gonec.gateway.v1.ChatService.DeleteMessage:input_type -> gonec.gateway.v1.DeleteMessageRequest
+	13, // 34: gonec.gateway.v1.ChatService.React:input_type -> gonec.gateway.v1.ReactRequest
+	15, // 35: gonec.gateway.v1.ChatService.ListThread:input_type -> gonec.gateway.v1.ListThreadRequest
+	17, // 36: gonec.gateway.v1.ChatService.LookupUser:input_type -> gonec.gateway.v1.LookupUserRequest
+	19, // 37: gonec.gateway.v1.ChatService.GetPresence:input_type -> gonec.gateway.v1.GetPresenceRequest
+	21, // 38: gonec.gateway.v1.ChatService.WatchPresence:input_type -> gonec.gateway.v1.WatchPresenceRequest
+	22, // 39: gonec.gateway.v1.ChatService.SetPresenceHidden:input_type -> gonec.gateway.v1.SetPresenceHiddenRequest
+	24, // 40: gonec.gateway.v1.ChatService.BlockUser:input_type -> gonec.gateway.v1.BlockUserRequest
+	26, // 41: gonec.gateway.v1.ChatService.UnblockUser:input_type -> gonec.gateway.v1.UnblockUserRequest
+	28, // 42: gonec.gateway.v1.ChatService.ListBlocks:input_type -> gonec.gateway.v1.ListBlocksRequest
+	1,  // 43: gonec.gateway.v1.ChatService.Send:output_type -> gonec.gateway.v1.SendReply
+	32, // 44: gonec.gateway.v1.ChatService.Listen:output_type -> gonec.shared.v1.Message
+	31, // 45: gonec.gateway.v1.ChatService.Events:output_type -> gonec.shared.v1.Event
+	7,  // 46: gonec.gateway.v1.ChatService.Chat:output_type -> gonec.gateway.v1.ChatReply
+	10, // 47: gonec.gateway.v1.ChatService.EditMessage:output_type -> gonec.gateway.v1.EditMessageReply
+	12, // 48: gonec.gateway.v1.ChatService.DeleteMessage:output_type -> gonec.gateway.v1.DeleteMessageReply
+	14, // 49: gonec.gateway.v1.ChatService.React:output_type -> gonec.gateway.v1.ReactReply
+	16, // 50: gonec.gateway.v1.ChatService.ListThread:output_type -> gonec.gateway.v1.ListThreadReply
+	18, // 51: gonec.gateway.v1.ChatService.LookupUser:output_type -> gonec.gateway.v1.LookupUserReply
+	20, // 52: gonec.gateway.v1.ChatService.GetPresence:output_type -> gonec.gateway.v1.GetPresenceReply
+	37, // 53: gonec.gateway.v1.ChatService.WatchPresence:output_type -> gonec.shared.v1.Presence
+	23, // 54: gonec.gateway.v1.ChatService.SetPresenceHidden:output_type -> gonec.gateway.v1.SetPresenceHiddenReply
+	25, // 55: gonec.gateway.v1.ChatService.BlockUser:output_type -> gonec.gateway.v1.BlockUserReply
+	27, // 56: gonec.gateway.v1.ChatService.UnblockUser:output_type -> gonec.gateway.v1.UnblockUserReply
+	29, // 57: gonec.gateway.v1.ChatService.ListBlocks:output_type -> gonec.gateway.v1.ListBlocksReply
+	43, // [43:58] is the sub-list for method output_type
+	28, // [28:43] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_gateway_chat_proto_init() }
@@ -217,13 +1908,21 @@ func file_gateway_chat_proto_init() {
 	if File_gateway_chat_proto != nil {
 		return
 	}
+	file_gateway_chat_proto_msgTypes[4].OneofWrappers = []any{
+		(*ChatRequest_Open)(nil),
+		(*ChatRequest_Send)(nil),
+	}
+	file_gateway_chat_proto_msgTypes[7].OneofWrappers = []any{
+		(*ChatReply_Event)(nil),
+		(*ChatReply_Ack)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gateway_chat_proto_rawDesc), len(file_gateway_chat_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -26,6 +26,7 @@ type ServerIdentity struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	IpAddress     string                 `protobuf:"bytes,1,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
 	PublicKey     []byte                 `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Host          string                 `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -74,6 +75,13 @@ func (x *ServerIdentity) GetPublicKey() []byte {
 	return nil
 }
 
+func (x *ServerIdentity) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
 type InviteCredential struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -194,6 +202,66 @@ func (x *InviteTicket) GetCredential() *InviteCredential {
 	return nil
 }
 
+type UserIdentity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PublicKey     []byte                 `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserIdentity) Reset() {
+	*x = UserIdentity{}
+	mi := &file_shared_auth_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserIdentity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserIdentity) ProtoMessage() {}
+
+func (x *UserIdentity) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_auth_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserIdentity.ProtoReflect.Descriptor instead.
+func (*UserIdentity) Descriptor() ([]byte, []int) {
+	return file_shared_auth_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UserIdentity) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserIdentity) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UserIdentity) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
 type Session struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -205,7 +273,7 @@ type Session struct {
 
 func (x *Session) Reset() {
 	*x = Session{}
-	mi := &file_shared_auth_proto_msgTypes[3]
+	mi := &file_shared_auth_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -217,7 +285,7 @@ func (x *Session) String() string {
 func (*Session) ProtoMessage() {}
 
 func (x *Session) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_auth_proto_msgTypes[3]
+	mi := &file_shared_auth_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -230,7 +298,7 @@ func (x *Session) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Session.ProtoReflect.Descriptor instead.
 func (*Session) Descriptor() ([]byte, []int) {
-	return file_shared_auth_proto_rawDescGZIP(), []int{3}
+	return file_shared_auth_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Session) GetId() string {
@@ -254,16 +322,93 @@ func (x *Session) GetToken() []byte {
 	return nil
 }
 
+type KeyRollover struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OldKey        []byte                 `protobuf:"bytes,1,opt,name=old_key,json=oldKey,proto3" json:"old_key,omitempty"`
+	NewKey        []byte                 `protobuf:"bytes,2,opt,name=new_key,json=newKey,proto3" json:"new_key,omitempty"`
+	NotBefore     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	NotAfter      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	Signature     []byte                 `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyRollover) Reset() {
+	*x = KeyRollover{}
+	mi := &file_shared_auth_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyRollover) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRollover) ProtoMessage() {}
+
+func (x *KeyRollover) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_auth_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRollover.ProtoReflect.Descriptor instead.
+func (*KeyRollover) Descriptor() ([]byte, []int) {
+	return file_shared_auth_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *KeyRollover) GetOldKey() []byte {
+	if x != nil {
+		return x.OldKey
+	}
+	return nil
+}
+
+func (x *KeyRollover) GetNewKey() []byte {
+	if x != nil {
+		return x.NewKey
+	}
+	return nil
+}
+
+func (x *KeyRollover) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
+
+func (x *KeyRollover) GetNotAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotAfter
+	}
+	return nil
+}
+
+func (x *KeyRollover) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
 var File_shared_auth_proto protoreflect.FileDescriptor
 
 const file_shared_auth_proto_rawDesc = "" +
 	"\n" +
-	"\x11shared/auth.proto\x12\x0fgonec.shared.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"N\n" +
+	"\x11shared/auth.proto\x12\x0fgonec.shared.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"b\n" +
 	"\x0eServerIdentity\x12\x1d\n" +
 	"\n" +
 	"ip_address\x18\x01 \x01(\tR\tipAddress\x12\x1d\n" +
 	"\n" +
-	"public_key\x18\x02 \x01(\fR\tpublicKey\"\xb5\x01\n" +
+	"public_key\x18\x02 \x01(\fR\tpublicKey\x12\x12\n" +
+	"\x04host\x18\x03 \x01(\tR\x04host\"\xb5\x01\n" +
 	"\x10InviteCredential\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
 	"\x05token\x18\x02 \x01(\fR\x05token\x129\n" +
@@ -274,11 +419,23 @@ const file_shared_auth_proto_rawDesc = "" +
 	"\x06server\x18\x01 \x01(\v2\x1f.gonec.shared.v1.ServerIdentityR\x06server\x12A\n" +
 	"\n" +
 	"credential\x18\x02 \x01(\v2!.gonec.shared.v1.InviteCredentialR\n" +
-	"credential\"H\n" +
+	"credential\"Q\n" +
+	"\fUserIdentity\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x03 \x01(\fR\tpublicKey\"H\n" +
 	"\aSession\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
-	"\x05token\x18\x03 \x01(\fR\x05tokenB(Z&github.com/charadev96/gonec/gen/sharedb\x06proto3"
+	"\x05token\x18\x03 \x01(\fR\x05token\"\xd1\x01\n" +
+	"\vKeyRollover\x12\x17\n" +
+	"\aold_key\x18\x01 \x01(\fR\x06oldKey\x12\x17\n" +
+	"\anew_key\x18\x02 \x01(\fR\x06newKey\x129\n" +
+	"\n" +
+	"not_before\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tnotBefore\x127\n" +
+	"\tnot_after\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bnotAfter\x12\x1c\n" +
+	"\tsignature\x18\x05 \x01(\fR\tsignatureB(Z&github.com/charadev96/gonec/gen/sharedb\x06proto3"
 
 var (
 	file_shared_auth_proto_rawDescOnce sync.Once
@@ -292,24 +449,28 @@ func file_shared_auth_proto_rawDescGZIP() []byte {
 	return file_shared_auth_proto_rawDescData
 }
 
-var file_shared_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_shared_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_shared_auth_proto_goTypes = []any{
 	(*ServerIdentity)(nil),        // 0: gonec.shared.v1.ServerIdentity
 	(*InviteCredential)(nil),      // 1: gonec.shared.v1.InviteCredential
 	(*InviteTicket)(nil),          // 2: gonec.shared.v1.InviteTicket
-	(*Session)(nil),               // 3: gonec.shared.v1.Session
-	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	(*UserIdentity)(nil),          // 3: gonec.shared.v1.UserIdentity
+	(*Session)(nil),               // 4: gonec.shared.v1.Session
+	(*KeyRollover)(nil),           // 5: gonec.shared.v1.KeyRollover
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
 }
 var file_shared_auth_proto_depIdxs = []int32{
-	4, // 0: gonec.shared.v1.InviteCredential.not_before:type_name -> google.protobuf.Timestamp
-	4, // 1: gonec.shared.v1.InviteCredential.not_after:type_name -> google.protobuf.Timestamp
+	6, // 0: gonec.shared.v1.InviteCredential.not_before:type_name -> google.protobuf.Timestamp
+	6, // 1: gonec.shared.v1.InviteCredential.not_after:type_name -> google.protobuf.Timestamp
 	0, // 2: gonec.shared.v1.InviteTicket.server:type_name -> gonec.shared.v1.ServerIdentity
 	1, // 3: gonec.shared.v1.InviteTicket.credential:type_name -> gonec.shared.v1.InviteCredential
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	6, // 4: gonec.shared.v1.KeyRollover.not_before:type_name -> google.protobuf.Timestamp
+	6, // 5: gonec.shared.v1.KeyRollover.not_after:type_name -> google.protobuf.Timestamp
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_shared_auth_proto_init() }
@@ -323,7 +484,7 @@ func file_shared_auth_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shared_auth_proto_rawDesc), len(file_shared_auth_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -22,6 +22,66 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type PendingTrust struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	PublicKey     []byte                 `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Fingerprint   string                 `protobuf:"bytes,3,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingTrust) Reset() {
+	*x = PendingTrust{}
+	mi := &file_user_auth_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingTrust) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingTrust) ProtoMessage() {}
+
+func (x *PendingTrust) ProtoReflect() protoreflect.Message {
+	mi := &file_user_auth_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingTrust.ProtoReflect.Descriptor instead.
+func (*PendingTrust) Descriptor() ([]byte, []int) {
+	return file_user_auth_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PendingTrust) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *PendingTrust) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *PendingTrust) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
 type RegisterRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
@@ -32,7 +92,7 @@ type RegisterRequest struct {
 
 func (x *RegisterRequest) Reset() {
 	*x = RegisterRequest{}
-	mi := &file_user_auth_proto_msgTypes[0]
+	mi := &file_user_auth_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -44,7 +104,7 @@ func (x *RegisterRequest) String() string {
 func (*RegisterRequest) ProtoMessage() {}
 
 func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_auth_proto_msgTypes[0]
+	mi := &file_user_auth_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -57,7 +117,7 @@ func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
 func (*RegisterRequest) Descriptor() ([]byte, []int) {
-	return file_user_auth_proto_rawDescGZIP(), []int{0}
+	return file_user_auth_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *RegisterRequest) GetConnectionId() string {
@@ -82,7 +142,7 @@ type RegisterReply struct {
 
 func (x *RegisterReply) Reset() {
 	*x = RegisterReply{}
-	mi := &file_user_auth_proto_msgTypes[1]
+	mi := &file_user_auth_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -94,7 +154,7 @@ func (x *RegisterReply) String() string {
 func (*RegisterReply) ProtoMessage() {}
 
 func (x *RegisterReply) ProtoReflect() protoreflect.Message {
-	mi := &file_user_auth_proto_msgTypes[1]
+	mi := &file_user_auth_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -107,7 +167,7 @@ func (x *RegisterReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterReply.ProtoReflect.Descriptor instead.
 func (*RegisterReply) Descriptor() ([]byte, []int) {
-	return file_user_auth_proto_rawDescGZIP(), []int{1}
+	return file_user_auth_proto_rawDescGZIP(), []int{2}
 }
 
 type LoginRequest struct {
@@ -119,7 +179,7 @@ type LoginRequest struct {
 
 func (x *LoginRequest) Reset() {
 	*x = LoginRequest{}
-	mi := &file_user_auth_proto_msgTypes[2]
+	mi := &file_user_auth_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -131,7 +191,7 @@ func (x *LoginRequest) String() string {
 func (*LoginRequest) ProtoMessage() {}
 
 func (x *LoginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_auth_proto_msgTypes[2]
+	mi := &file_user_auth_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -144,7 +204,7 @@ func (x *LoginRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
 func (*LoginRequest) Descriptor() ([]byte, []int) {
-	return file_user_auth_proto_rawDescGZIP(), []int{2}
+	return file_user_auth_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *LoginRequest) GetConnectionId() string {
@@ -162,7 +222,7 @@ type LoginReply struct {
 
 func (x *LoginReply) Reset() {
 	*x = LoginReply{}
-	mi := &file_user_auth_proto_msgTypes[3]
+	mi := &file_user_auth_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -174,7 +234,7 @@ func (x *LoginReply) String() string {
 func (*LoginReply) ProtoMessage() {}
 
 func (x *LoginReply) ProtoReflect() protoreflect.Message {
-	mi := &file_user_auth_proto_msgTypes[3]
+	mi := &file_user_auth_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -187,18 +247,19 @@ func (x *LoginReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginReply.ProtoReflect.Descriptor instead.
 func (*LoginReply) Descriptor() ([]byte, []int) {
-	return file_user_auth_proto_rawDescGZIP(), []int{3}
+	return file_user_auth_proto_rawDescGZIP(), []int{4}
 }
 
 type LogoutRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LogoutRequest) Reset() {
 	*x = LogoutRequest{}
-	mi := &file_user_auth_proto_msgTypes[4]
+	mi := &file_user_auth_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -210,7 +271,7 @@ func (x *LogoutRequest) String() string {
 func (*LogoutRequest) ProtoMessage() {}
 
 func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_auth_proto_msgTypes[4]
+	mi := &file_user_auth_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -223,7 +284,14 @@ func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
 func (*LogoutRequest) Descriptor() ([]byte, []int) {
-	return file_user_auth_proto_rawDescGZIP(), []int{4}
+	return file_user_auth_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LogoutRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
 }
 
 type LogoutReply struct {
@@ -234,7 +302,7 @@ type LogoutReply struct {
 
 func (x *LogoutReply) Reset() {
 	*x = LogoutReply{}
-	mi := &file_user_auth_proto_msgTypes[5]
+	mi := &file_user_auth_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -246,7 +314,7 @@ func (x *LogoutReply) String() string {
 func (*LogoutReply) ProtoMessage() {}
 
 func (x *LogoutReply) ProtoReflect() protoreflect.Message {
-	mi := &file_user_auth_proto_msgTypes[5]
+	mi := &file_user_auth_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -259,14 +327,347 @@ func (x *LogoutReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogoutReply.ProtoReflect.Descriptor instead.
 func (*LogoutReply) Descriptor() ([]byte, []int) {
-	return file_user_auth_proto_rawDescGZIP(), []int{5}
+	return file_user_auth_proto_rawDescGZIP(), []int{6}
+}
+
+type UnlockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Passphrase    string                 `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockRequest) Reset() {
+	*x = UnlockRequest{}
+	mi := &file_user_auth_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockRequest) ProtoMessage() {}
+
+func (x *UnlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_auth_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockRequest.ProtoReflect.Descriptor instead.
+func (*UnlockRequest) Descriptor() ([]byte, []int) {
+	return file_user_auth_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UnlockRequest) GetPassphrase() string {
+	if x != nil {
+		return x.Passphrase
+	}
+	return ""
+}
+
+type UnlockReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockReply) Reset() {
+	*x = UnlockReply{}
+	mi := &file_user_auth_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockReply) ProtoMessage() {}
+
+func (x *UnlockReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_auth_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockReply.ProtoReflect.Descriptor instead.
+func (*UnlockReply) Descriptor() ([]byte, []int) {
+	return file_user_auth_proto_rawDescGZIP(), []int{8}
+}
+
+type GetPendingTrustRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPendingTrustRequest) Reset() {
+	*x = GetPendingTrustRequest{}
+	mi := &file_user_auth_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPendingTrustRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPendingTrustRequest) ProtoMessage() {}
+
+func (x *GetPendingTrustRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_auth_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPendingTrustRequest.ProtoReflect.Descriptor instead.
+func (*GetPendingTrustRequest) Descriptor() ([]byte, []int) {
+	return file_user_auth_proto_rawDescGZIP(), []int{9}
+}
+
+type GetPendingTrustReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trusts        []*PendingTrust        `protobuf:"bytes,1,rep,name=trusts,proto3" json:"trusts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPendingTrustReply) Reset() {
+	*x = GetPendingTrustReply{}
+	mi := &file_user_auth_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPendingTrustReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPendingTrustReply) ProtoMessage() {}
+
+func (x *GetPendingTrustReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_auth_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPendingTrustReply.ProtoReflect.Descriptor instead.
+func (*GetPendingTrustReply) Descriptor() ([]byte, []int) {
+	return file_user_auth_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetPendingTrustReply) GetTrusts() []*PendingTrust {
+	if x != nil {
+		return x.Trusts
+	}
+	return nil
+}
+
+type AcceptTrustRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	Fingerprint   string                 `protobuf:"bytes,2,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptTrustRequest) Reset() {
+	*x = AcceptTrustRequest{}
+	mi := &file_user_auth_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptTrustRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptTrustRequest) ProtoMessage() {}
+
+func (x *AcceptTrustRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_auth_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptTrustRequest.ProtoReflect.Descriptor instead.
+func (*AcceptTrustRequest) Descriptor() ([]byte, []int) {
+	return file_user_auth_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AcceptTrustRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *AcceptTrustRequest) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+type AcceptTrustReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptTrustReply) Reset() {
+	*x = AcceptTrustReply{}
+	mi := &file_user_auth_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptTrustReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptTrustReply) ProtoMessage() {}
+
+func (x *AcceptTrustReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_auth_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptTrustReply.ProtoReflect.Descriptor instead.
+func (*AcceptTrustReply) Descriptor() ([]byte, []int) {
+	return file_user_auth_proto_rawDescGZIP(), []int{12}
+}
+
+type RejectTrustRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectTrustRequest) Reset() {
+	*x = RejectTrustRequest{}
+	mi := &file_user_auth_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectTrustRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectTrustRequest) ProtoMessage() {}
+
+func (x *RejectTrustRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_auth_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectTrustRequest.ProtoReflect.Descriptor instead.
+func (*RejectTrustRequest) Descriptor() ([]byte, []int) {
+	return file_user_auth_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RejectTrustRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+type RejectTrustReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectTrustReply) Reset() {
+	*x = RejectTrustReply{}
+	mi := &file_user_auth_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectTrustReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectTrustReply) ProtoMessage() {}
+
+func (x *RejectTrustReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_auth_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectTrustReply.ProtoReflect.Descriptor instead.
+func (*RejectTrustReply) Descriptor() ([]byte, []int) {
+	return file_user_auth_proto_rawDescGZIP(), []int{14}
 }
 
 var File_user_auth_proto protoreflect.FileDescriptor
 
 const file_user_auth_proto_rawDesc = "" +
 	"\n" +
-	"\x0fuser/auth.proto\x12\rgonec.user.v1\x1a\x11shared/auth.proto\"m\n" +
+	"\x0fuser/auth.proto\x12\rgonec.user.v1\x1a\x11shared/auth.proto\"t\n" +
+	"\fPendingTrust\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x02 \x01(\fR\tpublicKey\x12 \n" +
+	"\vfingerprint\x18\x03 \x01(\tR\vfingerprint\"m\n" +
 	"\x0fRegisterRequest\x12#\n" +
 	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x125\n" +
 	"\x06ticket\x18\x02 \x01(\v2\x1d.gonec.shared.v1.InviteTicketR\x06ticket\"\x0f\n" +
@@ -274,13 +675,33 @@ const file_user_auth_proto_rawDesc = "" +
 	"\fLoginRequest\x12#\n" +
 	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\"\f\n" +
 	"\n" +
-	"LoginReply\"\x0f\n" +
-	"\rLogoutRequest\"\r\n" +
-	"\vLogoutReply2\xdc\x01\n" +
+	"LoginReply\"4\n" +
+	"\rLogoutRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\"\r\n" +
+	"\vLogoutReply\"/\n" +
+	"\rUnlockRequest\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x01 \x01(\tR\n" +
+	"passphrase\"\r\n" +
+	"\vUnlockReply\"\x18\n" +
+	"\x16GetPendingTrustRequest\"K\n" +
+	"\x14GetPendingTrustReply\x123\n" +
+	"\x06trusts\x18\x01 \x03(\v2\x1b.gonec.user.v1.PendingTrustR\x06trusts\"[\n" +
+	"\x12AcceptTrustRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12 \n" +
+	"\vfingerprint\x18\x02 \x01(\tR\vfingerprint\"\x12\n" +
+	"\x10AcceptTrustReply\"9\n" +
+	"\x12RejectTrustRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\"\x12\n" +
+	"\x10RejectTrustReply2\xa5\x04\n" +
 	"\vAuthService\x12H\n" +
 	"\bRegister\x12\x1e.gonec.user.v1.RegisterRequest\x1a\x1c.gonec.user.v1.RegisterReply\x12?\n" +
 	"\x05Login\x12\x1b.gonec.user.v1.LoginRequest\x1a\x19.gonec.user.v1.LoginReply\x12B\n" +
-	"\x06Logout\x12\x1c.gonec.user.v1.LogoutRequest\x1a\x1a.gonec.user.v1.LogoutReplyB&Z$github.com/charadev96/gonec/gen/userb\x06proto3"
+	"\x06Logout\x12\x1c.gonec.user.v1.LogoutRequest\x1a\x1a.gonec.user.v1.LogoutReply\x12B\n" +
+	"\x06Unlock\x12\x1c.gonec.user.v1.UnlockRequest\x1a\x1a.gonec.user.v1.UnlockReply\x12]\n" +
+	"\x0fGetPendingTrust\x12%.gonec.user.v1.GetPendingTrustRequest\x1a#.gonec.user.v1.GetPendingTrustReply\x12Q\n" +
+	"\vAcceptTrust\x12!.gonec.user.v1.AcceptTrustRequest\x1a\x1f.gonec.user.v1.AcceptTrustReply\x12Q\n" +
+	"\vRejectTrust\x12!.gonec.user.v1.RejectTrustRequest\x1a\x1f.gonec.user.v1.RejectTrustReplyB&Z$github.com/charadev96/gonec/gen/userb\x06proto3"
 
 var (
 	file_user_auth_proto_rawDescOnce sync.Once
@@ -294,29 +715,47 @@ func file_user_auth_proto_rawDescGZIP() []byte {
 	return file_user_auth_proto_rawDescData
 }
 
-var file_user_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_user_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_user_auth_proto_goTypes = []any{
-	(*RegisterRequest)(nil),     // 0: gonec.user.v1.RegisterRequest
-	(*RegisterReply)(nil),       // 1: gonec.user.v1.RegisterReply
-	(*LoginRequest)(nil),        // 2: gonec.user.v1.LoginRequest
-	(*LoginReply)(nil),          // 3: gonec.user.v1.LoginReply
-	(*LogoutRequest)(nil),       // 4: gonec.user.v1.LogoutRequest
-	(*LogoutReply)(nil),         // 5: gonec.user.v1.LogoutReply
-	(*shared.InviteTicket)(nil), // 6: gonec.shared.v1.InviteTicket
+	(*PendingTrust)(nil),           // 0: gonec.user.v1.PendingTrust
+	(*RegisterRequest)(nil),        // 1: gonec.user.v1.RegisterRequest
+	(*RegisterReply)(nil),          // 2: gonec.user.v1.RegisterReply
+	(*LoginRequest)(nil),           // 3: gonec.user.v1.LoginRequest
+	(*LoginReply)(nil),             // 4: gonec.user.v1.LoginReply
+	(*LogoutRequest)(nil),          // 5: gonec.user.v1.LogoutRequest
+	(*LogoutReply)(nil),            // 6: gonec.user.v1.LogoutReply
+	(*UnlockRequest)(nil),          // 7: gonec.user.v1.UnlockRequest
+	(*UnlockReply)(nil),            // 8: gonec.user.v1.UnlockReply
+	(*GetPendingTrustRequest)(nil), // 9: gonec.user.v1.GetPendingTrustRequest
+	(*GetPendingTrustReply)(nil),   // 10: gonec.user.v1.GetPendingTrustReply
+	(*AcceptTrustRequest)(nil),     // 11: gonec.user.v1.AcceptTrustRequest
+	(*AcceptTrustReply)(nil),       // 12: gonec.user.v1.AcceptTrustReply
+	(*RejectTrustRequest)(nil),     // 13: gonec.user.v1.RejectTrustRequest
+	(*RejectTrustReply)(nil),       // 14: gonec.user.v1.RejectTrustReply
+	(*shared.InviteTicket)(nil),    // 15: gonec.shared.v1.InviteTicket
 }
 var file_user_auth_proto_depIdxs = []int32{
-	6, // 0: gonec.user.v1.RegisterRequest.ticket:type_name -> gonec.shared.v1.InviteTicket
-	0, // 1: gonec.user.v1.AuthService.Register:input_type -> gonec.user.v1.RegisterRequest
-	2, // 2: gonec.user.v1.AuthService.Login:input_type -> gonec.user.v1.LoginRequest
-	4, // 3: gonec.user.v1.AuthService.Logout:input_type -> gonec.user.v1.LogoutRequest
-	1, // 4: gonec.user.v1.AuthService.Register:output_type -> gonec.user.v1.RegisterReply
-	3, // 5: gonec.user.v1.AuthService.Login:output_type -> gonec.user.v1.LoginReply
-	5, // 6: gonec.user.v1.AuthService.Logout:output_type -> gonec.user.v1.LogoutReply
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	15, // 0: gonec.user.v1.RegisterRequest.ticket:type_name -> gonec.shared.v1.InviteTicket
+	0,  // 1: gonec.user.v1.GetPendingTrustReply.trusts:type_name -> gonec.user.v1.PendingTrust
+	1,  // 2: gonec.user.v1.AuthService.Register:input_type -> gonec.user.v1.RegisterRequest
+	3,  // 3: gonec.user.v1.AuthService.Login:input_type -> gonec.user.v1.LoginRequest
+	5,  // 4: gonec.user.v1.AuthService.Logout:input_type -> gonec.user.v1.LogoutRequest
+	7,  // 5: gonec.user.v1.AuthService.Unlock:input_type -> gonec.user.v1.UnlockRequest
+	9,  // 6: gonec.user.v1.AuthService.GetPendingTrust:input_type -> gonec.user.v1.GetPendingTrustRequest
+	11, // 7: gonec.user.v1.AuthService.AcceptTrust:input_type -> gonec.user.v1.AcceptTrustRequest
+	13, // 8: gonec.user.v1.AuthService.RejectTrust:input_type -> gonec.user.v1.RejectTrustRequest
+	2,  // 9: gonec.user.v1.AuthService.Register:output_type -> gonec.user.v1.RegisterReply
+	4,  // 10: gonec.user.v1.AuthService.Login:output_type -> gonec.user.v1.LoginReply
+	6,  // 11: gonec.user.v1.AuthService.Logout:output_type -> gonec.user.v1.LogoutReply
+	8,  // 12: gonec.user.v1.AuthService.Unlock:output_type -> gonec.user.v1.UnlockReply
+	10, // 13: gonec.user.v1.AuthService.GetPendingTrust:output_type -> gonec.user.v1.GetPendingTrustReply
+	12, // 14: gonec.user.v1.AuthService.AcceptTrust:output_type -> gonec.user.v1.AcceptTrustReply
+	14, // 15: gonec.user.v1.AuthService.RejectTrust:output_type -> gonec.user.v1.RejectTrustReply
+	9,  // [9:16] is the sub-list for method output_type
+	2,  // [2:9] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_user_auth_proto_init() }
@@ -330,7 +769,7 @@ func file_user_auth_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_auth_proto_rawDesc), len(file_user_auth_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
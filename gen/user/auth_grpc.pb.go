@@ -19,9 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuthService_Register_FullMethodName = "/gonec.user.v1.AuthService/Register"
-	AuthService_Login_FullMethodName    = "/gonec.user.v1.AuthService/Login"
-	AuthService_Logout_FullMethodName   = "/gonec.user.v1.AuthService/Logout"
+	AuthService_Register_FullMethodName        = "/gonec.user.v1.AuthService/Register"
+	AuthService_Login_FullMethodName           = "/gonec.user.v1.AuthService/Login"
+	AuthService_Logout_FullMethodName          = "/gonec.user.v1.AuthService/Logout"
+	AuthService_Unlock_FullMethodName          = "/gonec.user.v1.AuthService/Unlock"
+	AuthService_GetPendingTrust_FullMethodName = "/gonec.user.v1.AuthService/GetPendingTrust"
+	AuthService_AcceptTrust_FullMethodName     = "/gonec.user.v1.AuthService/AcceptTrust"
+	AuthService_RejectTrust_FullMethodName     = "/gonec.user.v1.AuthService/RejectTrust"
 )
 
 // AuthServiceClient is the client API for AuthService service.
@@ -31,6 +35,10 @@ type AuthServiceClient interface {
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterReply, error)
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginReply, error)
 	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutReply, error)
+	Unlock(ctx context.Context, in *UnlockRequest, opts ...grpc.CallOption) (*UnlockReply, error)
+	GetPendingTrust(ctx context.Context, in *GetPendingTrustRequest, opts ...grpc.CallOption) (*GetPendingTrustReply, error)
+	AcceptTrust(ctx context.Context, in *AcceptTrustRequest, opts ...grpc.CallOption) (*AcceptTrustReply, error)
+	RejectTrust(ctx context.Context, in *RejectTrustRequest, opts ...grpc.CallOption) (*RejectTrustReply, error)
 }
 
 type authServiceClient struct {
@@ -71,6 +79,46 @@ func (c *authServiceClient) Logout(ctx context.Context, in *LogoutRequest, opts
 	return out, nil
 }
 
+func (c *authServiceClient) Unlock(ctx context.Context, in *UnlockRequest, opts ...grpc.CallOption) (*UnlockReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnlockReply)
+	err := c.cc.Invoke(ctx, AuthService_Unlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetPendingTrust(ctx context.Context, in *GetPendingTrustRequest, opts ...grpc.CallOption) (*GetPendingTrustReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPendingTrustReply)
+	err := c.cc.Invoke(ctx, AuthService_GetPendingTrust_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) AcceptTrust(ctx context.Context, in *AcceptTrustRequest, opts ...grpc.CallOption) (*AcceptTrustReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceptTrustReply)
+	err := c.cc.Invoke(ctx, AuthService_AcceptTrust_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RejectTrust(ctx context.Context, in *RejectTrustRequest, opts ...grpc.CallOption) (*RejectTrustReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RejectTrustReply)
+	err := c.cc.Invoke(ctx, AuthService_RejectTrust_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServiceServer is the server API for AuthService service.
 // All implementations must embed UnimplementedAuthServiceServer
 // for forward compatibility.
@@ -78,6 +126,10 @@ type AuthServiceServer interface {
 	Register(context.Context, *RegisterRequest) (*RegisterReply, error)
 	Login(context.Context, *LoginRequest) (*LoginReply, error)
 	Logout(context.Context, *LogoutRequest) (*LogoutReply, error)
+	Unlock(context.Context, *UnlockRequest) (*UnlockReply, error)
+	GetPendingTrust(context.Context, *GetPendingTrustRequest) (*GetPendingTrustReply, error)
+	AcceptTrust(context.Context, *AcceptTrustRequest) (*AcceptTrustReply, error)
+	RejectTrust(context.Context, *RejectTrustRequest) (*RejectTrustReply, error)
 	mustEmbedUnimplementedAuthServiceServer()
 }
 
@@ -97,6 +149,18 @@ func (UnimplementedAuthServiceServer) Login(context.Context, *LoginRequest) (*Lo
 func (UnimplementedAuthServiceServer) Logout(context.Context, *LogoutRequest) (*LogoutReply, error) {
 	return nil, status.Error(codes.Unimplemented, "method Logout not implemented")
 }
+func (UnimplementedAuthServiceServer) Unlock(context.Context, *UnlockRequest) (*UnlockReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method Unlock not implemented")
+}
+func (UnimplementedAuthServiceServer) GetPendingTrust(context.Context, *GetPendingTrustRequest) (*GetPendingTrustReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPendingTrust not implemented")
+}
+func (UnimplementedAuthServiceServer) AcceptTrust(context.Context, *AcceptTrustRequest) (*AcceptTrustReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcceptTrust not implemented")
+}
+func (UnimplementedAuthServiceServer) RejectTrust(context.Context, *RejectTrustRequest) (*RejectTrustReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method RejectTrust not implemented")
+}
 func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
 func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
 
@@ -172,6 +236,78 @@ func _AuthService_Logout_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Unlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Unlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Unlock(ctx, req.(*UnlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetPendingTrust_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPendingTrustRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetPendingTrust(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetPendingTrust_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetPendingTrust(ctx, req.(*GetPendingTrustRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_AcceptTrust_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptTrustRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).AcceptTrust(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_AcceptTrust_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).AcceptTrust(ctx, req.(*AcceptTrustRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RejectTrust_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectTrustRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RejectTrust(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RejectTrust_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RejectTrust(ctx, req.(*RejectTrustRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -191,6 +327,22 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Logout",
 			Handler:    _AuthService_Logout_Handler,
 		},
+		{
+			MethodName: "Unlock",
+			Handler:    _AuthService_Unlock_Handler,
+		},
+		{
+			MethodName: "GetPendingTrust",
+			Handler:    _AuthService_GetPendingTrust_Handler,
+		},
+		{
+			MethodName: "AcceptTrust",
+			Handler:    _AuthService_AcceptTrust_Handler,
+		},
+		{
+			MethodName: "RejectTrust",
+			Handler:    _AuthService_RejectTrust_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "user/auth.proto",
@@ -10,6 +10,7 @@ import (
 	shared "github.com/charadev96/gonec/gen/shared"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,10 +23,114 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ConnectionState int32
+
+const (
+	ConnectionState_CONNECTION_STATE_UNSPECIFIED  ConnectionState = 0
+	ConnectionState_CONNECTION_STATE_RECONNECTING ConnectionState = 1
+	ConnectionState_CONNECTION_STATE_CONNECTED    ConnectionState = 2
+	ConnectionState_CONNECTION_STATE_DISCONNECTED ConnectionState = 3
+)
+
+// Enum value maps for ConnectionState.
+var (
+	ConnectionState_name = map[int32]string{
+		0: "CONNECTION_STATE_UNSPECIFIED",
+		1: "CONNECTION_STATE_RECONNECTING",
+		2: "CONNECTION_STATE_CONNECTED",
+		3: "CONNECTION_STATE_DISCONNECTED",
+	}
+	ConnectionState_value = map[string]int32{
+		"CONNECTION_STATE_UNSPECIFIED":  0,
+		"CONNECTION_STATE_RECONNECTING": 1,
+		"CONNECTION_STATE_CONNECTED":    2,
+		"CONNECTION_STATE_DISCONNECTED": 3,
+	}
+)
+
+func (x ConnectionState) Enum() *ConnectionState {
+	p := new(ConnectionState)
+	*p = x
+	return p
+}
+
+func (x ConnectionState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConnectionState) Descriptor() protoreflect.EnumDescriptor {
+	return file_user_chat_proto_enumTypes[0].Descriptor()
+}
+
+func (ConnectionState) Type() protoreflect.EnumType {
+	return &file_user_chat_proto_enumTypes[0]
+}
+
+func (x ConnectionState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConnectionState.Descriptor instead.
+func (ConnectionState) EnumDescriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{0}
+}
+
+type MessageDirection int32
+
+const (
+	MessageDirection_MESSAGE_DIRECTION_UNSPECIFIED MessageDirection = 0
+	MessageDirection_MESSAGE_DIRECTION_SENT        MessageDirection = 1
+	MessageDirection_MESSAGE_DIRECTION_RECEIVED    MessageDirection = 2
+)
+
+// Enum value maps for MessageDirection.
+var (
+	MessageDirection_name = map[int32]string{
+		0: "MESSAGE_DIRECTION_UNSPECIFIED",
+		1: "MESSAGE_DIRECTION_SENT",
+		2: "MESSAGE_DIRECTION_RECEIVED",
+	}
+	MessageDirection_value = map[string]int32{
+		"MESSAGE_DIRECTION_UNSPECIFIED": 0,
+		"MESSAGE_DIRECTION_SENT":        1,
+		"MESSAGE_DIRECTION_RECEIVED":    2,
+	}
+)
+
+func (x MessageDirection) Enum() *MessageDirection {
+	p := new(MessageDirection)
+	*p = x
+	return p
+}
+
+func (x MessageDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MessageDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_user_chat_proto_enumTypes[1].Descriptor()
+}
+
+func (MessageDirection) Type() protoreflect.EnumType {
+	return &file_user_chat_proto_enumTypes[1]
+}
+
+func (x MessageDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MessageDirection.Descriptor instead.
+func (MessageDirection) EnumDescriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{1}
+}
+
 type SendRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
 	Recipient     string                 `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
 	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	AttachmentIds []string               `protobuf:"bytes,4,rep,name=attachment_ids,json=attachmentIds,proto3" json:"attachment_ids,omitempty"`
+	ReplyTo       string                 `protobuf:"bytes,5,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -60,6 +165,13 @@ func (*SendRequest) Descriptor() ([]byte, []int) {
 	return file_user_chat_proto_rawDescGZIP(), []int{0}
 }
 
+func (x *SendRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
 func (x *SendRequest) GetRecipient() string {
 	if x != nil {
 		return x.Recipient
@@ -74,8 +186,25 @@ func (x *SendRequest) GetContent() string {
 	return ""
 }
 
+func (x *SendRequest) GetAttachmentIds() []string {
+	if x != nil {
+		return x.AttachmentIds
+	}
+	return nil
+}
+
+func (x *SendRequest) GetReplyTo() string {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return ""
+}
+
 type SendReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecipientId   string                 `protobuf:"bytes,1,opt,name=recipient_id,json=recipientId,proto3" json:"recipient_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ThreadId      string                 `protobuf:"bytes,3,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -110,8 +239,30 @@ func (*SendReply) Descriptor() ([]byte, []int) {
 	return file_user_chat_proto_rawDescGZIP(), []int{1}
 }
 
+func (x *SendReply) GetRecipientId() string {
+	if x != nil {
+		return x.RecipientId
+	}
+	return ""
+}
+
+func (x *SendReply) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *SendReply) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
 type ListenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionIds []string               `protobuf:"bytes,1,rep,name=connection_ids,json=connectionIds,proto3" json:"connection_ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -146,68 +297,2077 @@ func (*ListenRequest) Descriptor() ([]byte, []int) {
 	return file_user_chat_proto_rawDescGZIP(), []int{2}
 }
 
-var File_user_chat_proto protoreflect.FileDescriptor
+func (x *ListenRequest) GetConnectionIds() []string {
+	if x != nil {
+		return x.ConnectionIds
+	}
+	return nil
+}
 
-const file_user_chat_proto_rawDesc = "" +
-	"\n" +
-	"\x0fuser/chat.proto\x12\rgonec.user.v1\x1a\x11shared/chat.proto\"E\n" +
-	"\vSendRequest\x12\x1c\n" +
-	"\trecipient\x18\x02 \x01(\tR\trecipient\x12\x18\n" +
-	"\acontent\x18\x03 \x01(\tR\acontent\"\v\n" +
-	"\tSendReply\"\x0f\n" +
-	"\rListenRequest2\x8f\x01\n" +
-	"\vChatService\x12<\n" +
-	"\x04Send\x12\x1a.gonec.user.v1.SendRequest\x1a\x18.gonec.user.v1.SendReply\x12B\n" +
-	"\x06Listen\x12\x1c.gonec.user.v1.ListenRequest\x1a\x18.gonec.shared.v1.Message0\x01B&Z$github.com/charadev96/gonec/gen/userb\x06proto3"
+type ConnectionEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         ConnectionState        `protobuf:"varint,1,opt,name=state,proto3,enum=gonec.user.v1.ConnectionState" json:"state,omitempty"`
+	Attempt       uint32                 `protobuf:"varint,2,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-var (
-	file_user_chat_proto_rawDescOnce sync.Once
-	file_user_chat_proto_rawDescData []byte
-)
+func (x *ConnectionEvent) Reset() {
+	*x = ConnectionEvent{}
+	mi := &file_user_chat_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-func file_user_chat_proto_rawDescGZIP() []byte {
-	file_user_chat_proto_rawDescOnce.Do(func() {
-		file_user_chat_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_user_chat_proto_rawDesc), len(file_user_chat_proto_rawDesc)))
-	})
-	return file_user_chat_proto_rawDescData
+func (x *ConnectionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_user_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
-var file_user_chat_proto_goTypes = []any{
-	(*SendRequest)(nil),    // 0: gonec.user.v1.SendRequest
-	(*SendReply)(nil),      // 1: gonec.user.v1.SendReply
-	(*ListenRequest)(nil),  // 2: gonec.user.v1.ListenRequest
-	(*shared.Message)(nil), // 3: gonec.shared.v1.Message
+func (*ConnectionEvent) ProtoMessage() {}
+
+func (x *ConnectionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_user_chat_proto_depIdxs = []int32{
-	0, // 0: gonec.user.v1.ChatService.Send:input_type -> gonec.user.v1.SendRequest
-	2, // 1: gonec.user.v1.ChatService.Listen:input_type -> gonec.user.v1.ListenRequest
-	1, // 2: gonec.user.v1.ChatService.Send:output_type -> gonec.user.v1.SendReply
-	3, // 3: gonec.user.v1.ChatService.Listen:output_type -> gonec.shared.v1.Message
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+
+// Deprecated: Use ConnectionEvent.ProtoReflect.Descriptor instead.
+func (*ConnectionEvent) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{3}
 }
 
-func init() { file_user_chat_proto_init() }
-func file_user_chat_proto_init() {
-	if File_user_chat_proto != nil {
-		return
+func (x *ConnectionEvent) GetState() ConnectionState {
+	if x != nil {
+		return x.State
+	}
+	return ConnectionState_CONNECTION_STATE_UNSPECIFIED
+}
+
+func (x *ConnectionEvent) GetAttempt() uint32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *ConnectionEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ListenReply struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ListenReply_Message
+	//	*ListenReply_Event
+	Payload       isListenReply_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListenReply) Reset() {
+	*x = ListenReply{}
+	mi := &file_user_chat_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListenReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListenReply) ProtoMessage() {}
+
+func (x *ListenReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListenReply.ProtoReflect.Descriptor instead.
+func (*ListenReply) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListenReply) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *ListenReply) GetPayload() isListenReply_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ListenReply) GetMessage() *shared.Message {
+	if x != nil {
+		if x, ok := x.Payload.(*ListenReply_Message); ok {
+			return x.Message
+		}
+	}
+	return nil
+}
+
+func (x *ListenReply) GetEvent() *ConnectionEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*ListenReply_Event); ok {
+			return x.Event
+		}
+	}
+	return nil
+}
+
+type isListenReply_Payload interface {
+	isListenReply_Payload()
+}
+
+type ListenReply_Message struct {
+	Message *shared.Message `protobuf:"bytes,2,opt,name=message,proto3,oneof"`
+}
+
+type ListenReply_Event struct {
+	Event *ConnectionEvent `protobuf:"bytes,3,opt,name=event,proto3,oneof"`
+}
+
+func (*ListenReply_Message) isListenReply_Payload() {}
+
+func (*ListenReply_Event) isListenReply_Payload() {}
+
+type EventsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionIds   []string               `protobuf:"bytes,1,rep,name=connection_ids,json=connectionIds,proto3" json:"connection_ids,omitempty"`
+	PresenceUserIds []string               `protobuf:"bytes,2,rep,name=presence_user_ids,json=presenceUserIds,proto3" json:"presence_user_ids,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EventsRequest) Reset() {
+	*x = EventsRequest{}
+	mi := &file_user_chat_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventsRequest) ProtoMessage() {}
+
+func (x *EventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventsRequest.ProtoReflect.Descriptor instead.
+func (*EventsRequest) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EventsRequest) GetConnectionIds() []string {
+	if x != nil {
+		return x.ConnectionIds
+	}
+	return nil
+}
+
+func (x *EventsRequest) GetPresenceUserIds() []string {
+	if x != nil {
+		return x.PresenceUserIds
+	}
+	return nil
+}
+
+type EventsReply struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*EventsReply_Event
+	//	*EventsReply_Connection
+	Payload       isEventsReply_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventsReply) Reset() {
+	*x = EventsReply{}
+	mi := &file_user_chat_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventsReply) ProtoMessage() {}
+
+func (x *EventsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventsReply.ProtoReflect.Descriptor instead.
+func (*EventsReply) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EventsReply) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *EventsReply) GetPayload() isEventsReply_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *EventsReply) GetEvent() *shared.Event {
+	if x != nil {
+		if x, ok := x.Payload.(*EventsReply_Event); ok {
+			return x.Event
+		}
+	}
+	return nil
+}
+
+func (x *EventsReply) GetConnection() *ConnectionEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*EventsReply_Connection); ok {
+			return x.Connection
+		}
+	}
+	return nil
+}
+
+type isEventsReply_Payload interface {
+	isEventsReply_Payload()
+}
+
+type EventsReply_Event struct {
+	Event *shared.Event `protobuf:"bytes,2,opt,name=event,proto3,oneof"`
+}
+
+type EventsReply_Connection struct {
+	Connection *ConnectionEvent `protobuf:"bytes,3,opt,name=connection,proto3,oneof"`
+}
+
+func (*EventsReply_Event) isEventsReply_Payload() {}
+
+func (*EventsReply_Connection) isEventsReply_Payload() {}
+
+type EditMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditMessageRequest) Reset() {
+	*x = EditMessageRequest{}
+	mi := &file_user_chat_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMessageRequest) ProtoMessage() {}
+
+func (x *EditMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMessageRequest.ProtoReflect.Descriptor instead.
+func (*EditMessageRequest) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EditMessageRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type EditMessageReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Edit          *shared.MessageEdit    `protobuf:"bytes,1,opt,name=edit,proto3" json:"edit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditMessageReply) Reset() {
+	*x = EditMessageReply{}
+	mi := &file_user_chat_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMessageReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMessageReply) ProtoMessage() {}
+
+func (x *EditMessageReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMessageReply.ProtoReflect.Descriptor instead.
+func (*EditMessageReply) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EditMessageReply) GetEdit() *shared.MessageEdit {
+	if x != nil {
+		return x.Edit
+	}
+	return nil
+}
+
+type DeleteMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMessageRequest) Reset() {
+	*x = DeleteMessageRequest{}
+	mi := &file_user_chat_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMessageRequest) ProtoMessage() {}
+
+func (x *DeleteMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMessageRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMessageRequest) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteMessageRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *DeleteMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type DeleteMessageReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delete        *shared.MessageDelete  `protobuf:"bytes,1,opt,name=delete,proto3" json:"delete,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMessageReply) Reset() {
+	*x = DeleteMessageReply{}
+	mi := &file_user_chat_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMessageReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMessageReply) ProtoMessage() {}
+
+func (x *DeleteMessageReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMessageReply.ProtoReflect.Descriptor instead.
+func (*DeleteMessageReply) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteMessageReply) GetDelete() *shared.MessageDelete {
+	if x != nil {
+		return x.Delete
+	}
+	return nil
+}
+
+type ReactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Emoji         string                 `protobuf:"bytes,3,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	Remove        bool                   `protobuf:"varint,4,opt,name=remove,proto3" json:"remove,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactRequest) Reset() {
+	*x = ReactRequest{}
+	mi := &file_user_chat_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactRequest) ProtoMessage() {}
+
+func (x *ReactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactRequest.ProtoReflect.Descriptor instead.
+func (*ReactRequest) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReactRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *ReactRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ReactRequest) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *ReactRequest) GetRemove() bool {
+	if x != nil {
+		return x.Remove
+	}
+	return false
+}
+
+type ReactReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reaction      *shared.Reaction       `protobuf:"bytes,1,opt,name=reaction,proto3" json:"reaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactReply) Reset() {
+	*x = ReactReply{}
+	mi := &file_user_chat_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactReply) ProtoMessage() {}
+
+func (x *ReactReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactReply.ProtoReflect.Descriptor instead.
+func (*ReactReply) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReactReply) GetReaction() *shared.Reaction {
+	if x != nil {
+		return x.Reaction
+	}
+	return nil
+}
+
+type ListThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Limit         uint32                 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListThreadRequest) Reset() {
+	*x = ListThreadRequest{}
+	mi := &file_user_chat_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListThreadRequest) ProtoMessage() {}
+
+func (x *ListThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListThreadRequest.ProtoReflect.Descriptor instead.
+func (*ListThreadRequest) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListThreadRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *ListThreadRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ListThreadRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListThreadRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListThreadReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*shared.Message      `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListThreadReply) Reset() {
+	*x = ListThreadReply{}
+	mi := &file_user_chat_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListThreadReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListThreadReply) ProtoMessage() {}
+
+func (x *ListThreadReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListThreadReply.ProtoReflect.Descriptor instead.
+func (*ListThreadReply) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListThreadReply) GetMessages() []*shared.Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *ListThreadReply) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type StoredMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ConnectionId  string                 `protobuf:"bytes,2,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	Peer          string                 `protobuf:"bytes,3,opt,name=peer,proto3" json:"peer,omitempty"`
+	Direction     MessageDirection       `protobuf:"varint,4,opt,name=direction,proto3,enum=gonec.user.v1.MessageDirection" json:"direction,omitempty"`
+	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	MessageId     string                 `protobuf:"bytes,7,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	EditedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	Reactions     []*ReactionCount       `protobuf:"bytes,10,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	ReplyTo       string                 `protobuf:"bytes,11,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
+	ThreadId      string                 `protobuf:"bytes,12,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoredMessage) Reset() {
+	*x = StoredMessage{}
+	mi := &file_user_chat_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoredMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoredMessage) ProtoMessage() {}
+
+func (x *StoredMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoredMessage.ProtoReflect.Descriptor instead.
+func (*StoredMessage) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StoredMessage) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *StoredMessage) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *StoredMessage) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *StoredMessage) GetDirection() MessageDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return MessageDirection_MESSAGE_DIRECTION_UNSPECIFIED
+}
+
+func (x *StoredMessage) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *StoredMessage) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *StoredMessage) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *StoredMessage) GetEditedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EditedAt
+	}
+	return nil
+}
+
+func (x *StoredMessage) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *StoredMessage) GetReactions() []*ReactionCount {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+func (x *StoredMessage) GetReplyTo() string {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return ""
+}
+
+func (x *StoredMessage) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+type ReactionCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Emoji         string                 `protobuf:"bytes,1,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	Count         uint32                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactionCount) Reset() {
+	*x = ReactionCount{}
+	mi := &file_user_chat_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactionCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactionCount) ProtoMessage() {}
+
+func (x *ReactionCount) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactionCount.ProtoReflect.Descriptor instead.
+func (*ReactionCount) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReactionCount) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *ReactionCount) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ListMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	Peer          string                 `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
+	Limit         uint32                 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        int64                  `protobuf:"varint,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMessagesRequest) Reset() {
+	*x = ListMessagesRequest{}
+	mi := &file_user_chat_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMessagesRequest) ProtoMessage() {}
+
+func (x *ListMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListMessagesRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *ListMessagesRequest) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *ListMessagesRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListMessagesRequest) GetCursor() int64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+type ListMessagesReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*StoredMessage       `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	Cursor        int64                  `protobuf:"varint,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMessagesReply) Reset() {
+	*x = ListMessagesReply{}
+	mi := &file_user_chat_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMessagesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMessagesReply) ProtoMessage() {}
+
+func (x *ListMessagesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMessagesReply.ProtoReflect.Descriptor instead.
+func (*ListMessagesReply) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListMessagesReply) GetMessages() []*StoredMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *ListMessagesReply) GetCursor() int64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+type SearchMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Limit         uint32                 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        int64                  `protobuf:"varint,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMessagesRequest) Reset() {
+	*x = SearchMessagesRequest{}
+	mi := &file_user_chat_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMessagesRequest) ProtoMessage() {}
+
+func (x *SearchMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMessagesRequest.ProtoReflect.Descriptor instead.
+func (*SearchMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SearchMessagesRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchMessagesRequest) GetCursor() int64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+type SearchMessagesReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*StoredMessage       `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	Cursor        int64                  `protobuf:"varint,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMessagesReply) Reset() {
+	*x = SearchMessagesReply{}
+	mi := &file_user_chat_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMessagesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMessagesReply) ProtoMessage() {}
+
+func (x *SearchMessagesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMessagesReply.ProtoReflect.Descriptor instead.
+func (*SearchMessagesReply) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SearchMessagesReply) GetMessages() []*StoredMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *SearchMessagesReply) GetCursor() int64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+type LookupUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	User          string                 `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupUserRequest) Reset() {
+	*x = LookupUserRequest{}
+	mi := &file_user_chat_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupUserRequest) ProtoMessage() {}
+
+func (x *LookupUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupUserRequest.ProtoReflect.Descriptor instead.
+func (*LookupUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *LookupUserRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *LookupUserRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+type LookupUserReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *shared.UserIdentity   `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupUserReply) Reset() {
+	*x = LookupUserReply{}
+	mi := &file_user_chat_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupUserReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupUserReply) ProtoMessage() {}
+
+func (x *LookupUserReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupUserReply.ProtoReflect.Descriptor instead.
+func (*LookupUserReply) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *LookupUserReply) GetUser() *shared.UserIdentity {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type GetPresenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	UserIds       []string               `protobuf:"bytes,2,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPresenceRequest) Reset() {
+	*x = GetPresenceRequest{}
+	mi := &file_user_chat_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPresenceRequest) ProtoMessage() {}
+
+func (x *GetPresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPresenceRequest.ProtoReflect.Descriptor instead.
+func (*GetPresenceRequest) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetPresenceRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *GetPresenceRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type GetPresenceReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Presences     []*shared.Presence     `protobuf:"bytes,1,rep,name=presences,proto3" json:"presences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPresenceReply) Reset() {
+	*x = GetPresenceReply{}
+	mi := &file_user_chat_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPresenceReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPresenceReply) ProtoMessage() {}
+
+func (x *GetPresenceReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPresenceReply.ProtoReflect.Descriptor instead.
+func (*GetPresenceReply) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetPresenceReply) GetPresences() []*shared.Presence {
+	if x != nil {
+		return x.Presences
+	}
+	return nil
+}
+
+type WatchPresenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	UserIds       []string               `protobuf:"bytes,2,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchPresenceRequest) Reset() {
+	*x = WatchPresenceRequest{}
+	mi := &file_user_chat_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPresenceRequest) ProtoMessage() {}
+
+func (x *WatchPresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPresenceRequest.ProtoReflect.Descriptor instead.
+func (*WatchPresenceRequest) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *WatchPresenceRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *WatchPresenceRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type SetPresenceHiddenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	Hidden        bool                   `protobuf:"varint,2,opt,name=hidden,proto3" json:"hidden,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPresenceHiddenRequest) Reset() {
+	*x = SetPresenceHiddenRequest{}
+	mi := &file_user_chat_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPresenceHiddenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPresenceHiddenRequest) ProtoMessage() {}
+
+func (x *SetPresenceHiddenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPresenceHiddenRequest.ProtoReflect.Descriptor instead.
+func (*SetPresenceHiddenRequest) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SetPresenceHiddenRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *SetPresenceHiddenRequest) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
+type SetPresenceHiddenReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPresenceHiddenReply) Reset() {
+	*x = SetPresenceHiddenReply{}
+	mi := &file_user_chat_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPresenceHiddenReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPresenceHiddenReply) ProtoMessage() {}
+
+func (x *SetPresenceHiddenReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPresenceHiddenReply.ProtoReflect.Descriptor instead.
+func (*SetPresenceHiddenReply) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{27}
+}
+
+type BlockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	User          string                 `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserRequest) Reset() {
+	*x = BlockUserRequest{}
+	mi := &file_user_chat_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserRequest) ProtoMessage() {}
+
+func (x *BlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserRequest.ProtoReflect.Descriptor instead.
+func (*BlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *BlockUserRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *BlockUserRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+type BlockUserReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Block         *shared.Block          `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserReply) Reset() {
+	*x = BlockUserReply{}
+	mi := &file_user_chat_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserReply) ProtoMessage() {}
+
+func (x *BlockUserReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserReply.ProtoReflect.Descriptor instead.
+func (*BlockUserReply) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *BlockUserReply) GetBlock() *shared.Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+type UnblockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserRequest) Reset() {
+	*x = UnblockUserRequest{}
+	mi := &file_user_chat_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserRequest) ProtoMessage() {}
+
+func (x *UnblockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserRequest.ProtoReflect.Descriptor instead.
+func (*UnblockUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UnblockUserRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *UnblockUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UnblockUserReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserReply) Reset() {
+	*x = UnblockUserReply{}
+	mi := &file_user_chat_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserReply) ProtoMessage() {}
+
+func (x *UnblockUserReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserReply.ProtoReflect.Descriptor instead.
+func (*UnblockUserReply) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{31}
+}
+
+type ListBlocksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlocksRequest) Reset() {
+	*x = ListBlocksRequest{}
+	mi := &file_user_chat_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlocksRequest) ProtoMessage() {}
+
+func (x *ListBlocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlocksRequest.ProtoReflect.Descriptor instead.
+func (*ListBlocksRequest) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListBlocksRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+type ListBlocksReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blocks        []*shared.Block        `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlocksReply) Reset() {
+	*x = ListBlocksReply{}
+	mi := &file_user_chat_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlocksReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlocksReply) ProtoMessage() {}
+
+func (x *ListBlocksReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_chat_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlocksReply.ProtoReflect.Descriptor instead.
+func (*ListBlocksReply) Descriptor() ([]byte, []int) {
+	return file_user_chat_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListBlocksReply) GetBlocks() []*shared.Block {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+var File_user_chat_proto protoreflect.FileDescriptor
+
+const file_user_chat_proto_rawDesc = "" +
+	"\n" +
+	"\x0fuser/chat.proto\x12\rgonec.user.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x11shared/auth.proto\x1a\x11shared/chat.proto\"\xac\x01\n" +
+	"\vSendRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12\x1c\n" +
+	"\trecipient\x18\x02 \x01(\tR\trecipient\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12%\n" +
+	"\x0eattachment_ids\x18\x04 \x03(\tR\rattachmentIds\x12\x19\n" +
+	"\breply_to\x18\x05 \x01(\tR\areplyTo\"j\n" +
+	"\tSendReply\x12!\n" +
+	"\frecipient_id\x18\x01 \x01(\tR\vrecipientId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x1b\n" +
+	"\tthread_id\x18\x03 \x01(\tR\bthreadId\"6\n" +
+	"\rListenRequest\x12%\n" +
+	"\x0econnection_ids\x18\x01 \x03(\tR\rconnectionIds\"w\n" +
+	"\x0fConnectionEvent\x124\n" +
+	"\x05state\x18\x01 \x01(\x0e2\x1e.gonec.user.v1.ConnectionStateR\x05state\x12\x18\n" +
+	"\aattempt\x18\x02 \x01(\rR\aattempt\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xab\x01\n" +
+	"\vListenReply\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x124\n" +
+	"\amessage\x18\x02 \x01(\v2\x18.gonec.shared.v1.MessageH\x00R\amessage\x126\n" +
+	"\x05event\x18\x03 \x01(\v2\x1e.gonec.user.v1.ConnectionEventH\x00R\x05eventB\t\n" +
+	"\apayload\"b\n" +
+	"\rEventsRequest\x12%\n" +
+	"\x0econnection_ids\x18\x01 \x03(\tR\rconnectionIds\x12*\n" +
+	"\x11presence_user_ids\x18\x02 \x03(\tR\x0fpresenceUserIds\"\xaf\x01\n" +
+	"\vEventsReply\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12.\n" +
+	"\x05event\x18\x02 \x01(\v2\x16.gonec.shared.v1.EventH\x00R\x05event\x12@\n" +
+	"\n" +
+	"connection\x18\x03 \x01(\v2\x1e.gonec.user.v1.ConnectionEventH\x00R\n" +
+	"connectionB\t\n" +
+	"\apayload\"r\n" +
+	"\x12EditMessageRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\"D\n" +
+	"\x10EditMessageReply\x120\n" +
+	"\x04edit\x18\x01 \x01(\v2\x1c.gonec.shared.v1.MessageEditR\x04edit\"Z\n" +
+	"\x14DeleteMessageRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\"L\n" +
+	"\x12DeleteMessageReply\x126\n" +
+	"\x06delete\x18\x01 \x01(\v2\x1e.gonec.shared.v1.MessageDeleteR\x06delete\"\x80\x01\n" +
+	"\fReactRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x14\n" +
+	"\x05emoji\x18\x03 \x01(\tR\x05emoji\x12\x16\n" +
+	"\x06remove\x18\x04 \x01(\bR\x06remove\"C\n" +
+	"\n" +
+	"ReactReply\x125\n" +
+	"\breaction\x18\x01 \x01(\v2\x19.gonec.shared.v1.ReactionR\breaction\"\x85\x01\n" +
+	"\x11ListThreadRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\rR\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"_\n" +
+	"\x0fListThreadReply\x124\n" +
+	"\bmessages\x18\x01 \x03(\v2\x18.gonec.shared.v1.MessageR\bmessages\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\"\xf3\x03\n" +
+	"\rStoredMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12#\n" +
+	"\rconnection_id\x18\x02 \x01(\tR\fconnectionId\x12\x12\n" +
+	"\x04peer\x18\x03 \x01(\tR\x04peer\x12=\n" +
+	"\tdirection\x18\x04 \x01(\x0e2\x1f.gonec.user.v1.MessageDirectionR\tdirection\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\a \x01(\tR\tmessageId\x127\n" +
+	"\tedited_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\beditedAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12:\n" +
+	"\treactions\x18\n" +
+	" \x03(\v2\x1c.gonec.user.v1.ReactionCountR\treactions\x12\x19\n" +
+	"\breply_to\x18\v \x01(\tR\areplyTo\x12\x1b\n" +
+	"\tthread_id\x18\f \x01(\tR\bthreadId\";\n" +
+	"\rReactionCount\x12\x14\n" +
+	"\x05emoji\x18\x01 \x01(\tR\x05emoji\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\rR\x05count\"|\n" +
+	"\x13ListMessagesRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12\x12\n" +
+	"\x04peer\x18\x02 \x01(\tR\x04peer\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\rR\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\x03R\x06cursor\"e\n" +
+	"\x11ListMessagesReply\x128\n" +
+	"\bmessages\x18\x01 \x03(\v2\x1c.gonec.user.v1.StoredMessageR\bmessages\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\x03R\x06cursor\"~\n" +
+	"\x15SearchMessagesRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\rR\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\x03R\x06cursor\"g\n" +
+	"\x13SearchMessagesReply\x128\n" +
+	"\bmessages\x18\x01 \x03(\v2\x1c.gonec.user.v1.StoredMessageR\bmessages\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\x03R\x06cursor\"L\n" +
+	"\x11LookupUserRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12\x12\n" +
+	"\x04user\x18\x02 \x01(\tR\x04user\"D\n" +
+	"\x0fLookupUserReply\x121\n" +
+	"\x04user\x18\x01 \x01(\v2\x1d.gonec.shared.v1.UserIdentityR\x04user\"T\n" +
+	"\x12GetPresenceRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\tR\auserIds\"K\n" +
+	"\x10GetPresenceReply\x127\n" +
+	"\tpresences\x18\x01 \x03(\v2\x19.gonec.shared.v1.PresenceR\tpresences\"V\n" +
+	"\x14WatchPresenceRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\tR\auserIds\"W\n" +
+	"\x18SetPresenceHiddenRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12\x16\n" +
+	"\x06hidden\x18\x02 \x01(\bR\x06hidden\"\x18\n" +
+	"\x16SetPresenceHiddenReply\"K\n" +
+	"\x10BlockUserRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12\x12\n" +
+	"\x04user\x18\x02 \x01(\tR\x04user\">\n" +
+	"\x0eBlockUserReply\x12,\n" +
+	"\x05block\x18\x01 \x01(\v2\x16.gonec.shared.v1.BlockR\x05block\"R\n" +
+	"\x12UnblockUserRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x12\n" +
+	"\x10UnblockUserReply\"8\n" +
+	"\x11ListBlocksRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\"A\n" +
+	"\x0fListBlocksReply\x12.\n" +
+	"\x06blocks\x18\x01 \x03(\v2\x16.gonec.shared.v1.BlockR\x06blocks*\x99\x01\n" +
+	"\x0fConnectionState\x12 \n" +
+	"\x1cCONNECTION_STATE_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dCONNECTION_STATE_RECONNECTING\x10\x01\x12\x1e\n" +
+	"\x1aCONNECTION_STATE_CONNECTED\x10\x02\x12!\n" +
+	"\x1dCONNECTION_STATE_DISCONNECTED\x10\x03*q\n" +
+	"\x10MessageDirection\x12!\n" +
+	"\x1dMESSAGE_DIRECTION_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16MESSAGE_DIRECTION_SENT\x10\x01\x12\x1e\n" +
+	"\x1aMESSAGE_DIRECTION_RECEIVED\x10\x022\x91\n" +
+	"\n" +
+	"\vChatService\x12<\n" +
+	"\x04Send\x12\x1a.gonec.user.v1.SendRequest\x1a\x18.gonec.user.v1.SendReply\x12D\n" +
+	"\x06Listen\x12\x1c.gonec.user.v1.ListenRequest\x1a\x1a.gonec.user.v1.ListenReply0\x01\x12D\n" +
+	"\x06Events\x12\x1c.gonec.user.v1.EventsRequest\x1a\x1a.gonec.user.v1.EventsReply0\x01\x12Q\n" +
+	"\vEditMessage\x12!.gonec.user.v1.EditMessageRequest\x1a\x1f.gonec.user.v1.EditMessageReply\x12W\n" +
+	"\rDeleteMessage\x12#.gonec.user.v1.DeleteMessageRequest\x1a!.gonec.user.v1.DeleteMessageReply\x12?\n" +
+	"\x05React\x12\x1b.gonec.user.v1.ReactRequest\x1a\x19.gonec.user.v1.ReactReply\x12N\n" +
+	"\n" +
+	"ListThread\x12 .gonec.user.v1.ListThreadRequest\x1a\x1e.gonec.user.v1.ListThreadReply\x12T\n" +
+	"\fListMessages\x12\".gonec.user.v1.ListMessagesRequest\x1a .gonec.user.v1.ListMessagesReply\x12Z\n" +
+	"\x0eSearchMessages\x12$.gonec.user.v1.SearchMessagesRequest\x1a\".gonec.user.v1.SearchMessagesReply\x12N\n" +
+	"\n" +
+	"LookupUser\x12 .gonec.user.v1.LookupUserRequest\x1a\x1e.gonec.user.v1.LookupUserReply\x12Q\n" +
+	"\vGetPresence\x12!.gonec.user.v1.GetPresenceRequest\x1a\x1f.gonec.user.v1.GetPresenceReply\x12Q\n" +
+	"\rWatchPresence\x12#.gonec.user.v1.WatchPresenceRequest\x1a\x19.gonec.shared.v1.Presence0\x01\x12c\n" +
+	"\x11SetPresenceHidden\x12'.gonec.user.v1.SetPresenceHiddenRequest\x1a%.gonec.user.v1.SetPresenceHiddenReply\x12K\n" +
+	"\tBlockUser\x12\x1f.gonec.user.v1.BlockUserRequest\x1a\x1d.gonec.user.v1.BlockUserReply\x12Q\n" +
+	"\vUnblockUser\x12!.gonec.user.v1.UnblockUserRequest\x1a\x1f.gonec.user.v1.UnblockUserReply\x12N\n" +
+	"\n" +
+	"ListBlocks\x12 .gonec.user.v1.ListBlocksRequest\x1a\x1e.gonec.user.v1.ListBlocksReplyB&Z$github.com/charadev96/gonec/gen/userb\x06proto3"
+
+var (
+	file_user_chat_proto_rawDescOnce sync.Once
+	file_user_chat_proto_rawDescData []byte
+)
+
+func file_user_chat_proto_rawDescGZIP() []byte {
+	file_user_chat_proto_rawDescOnce.Do(func() {
+		file_user_chat_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_user_chat_proto_rawDesc), len(file_user_chat_proto_rawDesc)))
+	})
+	return file_user_chat_proto_rawDescData
+}
+
+var file_user_chat_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_user_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_user_chat_proto_goTypes = []any{
+	(ConnectionState)(0),             // 0: gonec.user.v1.ConnectionState
+	(MessageDirection)(0),            // 1: gonec.user.v1.MessageDirection
+	(*SendRequest)(nil),              // 2: gonec.user.v1.SendRequest
+	(*SendReply)(nil),                // 3: gonec.user.v1.SendReply
+	(*ListenRequest)(nil),            // 4: gonec.user.v1.ListenRequest
+	(*ConnectionEvent)(nil),          // 5: gonec.user.v1.ConnectionEvent
+	(*ListenReply)(nil),              // 6: gonec.user.v1.ListenReply
+	(*EventsRequest)(nil),            // 7: gonec.user.v1.EventsRequest
+	(*EventsReply)(nil),              // 8: gonec.user.v1.EventsReply
+	(*EditMessageRequest)(nil),       // 9: gonec.user.v1.EditMessageRequest
+	(*EditMessageReply)(nil),         // 10: gonec.user.v1.EditMessageReply
+	(*DeleteMessageRequest)(nil),     // 11: gonec.user.v1.DeleteMessageRequest
+	(*DeleteMessageReply)(nil),       // 12: gonec.user.v1.DeleteMessageReply
+	(*ReactRequest)(nil),             // 13: gonec.user.v1.ReactRequest
+	(*ReactReply)(nil),               // 14: gonec.user.v1.ReactReply
+	(*ListThreadRequest)(nil),        // 15: gonec.user.v1.ListThreadRequest
+	(*ListThreadReply)(nil),          // 16: gonec.user.v1.ListThreadReply
+	(*StoredMessage)(nil),            // 17: gonec.user.v1.StoredMessage
+	(*ReactionCount)(nil),            // 18: gonec.user.v1.ReactionCount
+	(*ListMessagesRequest)(nil),      // 19: gonec.user.v1.ListMessagesRequest
+	(*ListMessagesReply)(nil),        // 20: gonec.user.v1.ListMessagesReply
+	(*SearchMessagesRequest)(nil),    // 21: gonec.user.v1.SearchMessagesRequest
+	(*SearchMessagesReply)(nil),      // 22: gonec.user.v1.SearchMessagesReply
+	(*LookupUserRequest)(nil),        // 23: gonec.user.v1.LookupUserRequest
+	(*LookupUserReply)(nil),          // 24: gonec.user.v1.LookupUserReply
+	(*GetPresenceRequest)(nil),       // 25: gonec.user.v1.GetPresenceRequest
+	(*GetPresenceReply)(nil),         // 26: gonec.user.v1.GetPresenceReply
+	(*WatchPresenceRequest)(nil),     // 27: gonec.user.v1.WatchPresenceRequest
+	(*SetPresenceHiddenRequest)(nil), // 28: gonec.user.v1.SetPresenceHiddenRequest
+	(*SetPresenceHiddenReply)(nil),   // 29: gonec.user.v1.SetPresenceHiddenReply
+	(*BlockUserRequest)(nil),         // 30: gonec.user.v1.BlockUserRequest
+	(*BlockUserReply)(nil),           // 31: gonec.user.v1.BlockUserReply
+	(*UnblockUserRequest)(nil),       // 32: gonec.user.v1.UnblockUserRequest
+	(*UnblockUserReply)(nil),         // 33: gonec.user.v1.UnblockUserReply
+	(*ListBlocksRequest)(nil),        // 34: gonec.user.v1.ListBlocksRequest
+	(*ListBlocksReply)(nil),          // 35: gonec.user.v1.ListBlocksReply
+	(*shared.Message)(nil),           // 36: gonec.shared.v1.Message
+	(*shared.Event)(nil),             // 37: gonec.shared.v1.Event
+	(*shared.MessageEdit)(nil),       // 38: gonec.shared.v1.MessageEdit
+	(*shared.MessageDelete)(nil),     // 39: gonec.shared.v1.MessageDelete
+	(*shared.Reaction)(nil),          // 40: gonec.shared.v1.Reaction
+	(*timestamppb.Timestamp)(nil),    // 41: google.protobuf.Timestamp
+	(*shared.UserIdentity)(nil),      // 42: gonec.shared.v1.UserIdentity
+	(*shared.Presence)(nil),          // 43: gonec.shared.v1.Presence
+	(*shared.Block)(nil),             // 44: gonec.shared.v1.Block
+}
+var file_user_chat_proto_depIdxs = []int32{
+	0,  // 0: gonec.user.v1.ConnectionEvent.state:type_name -> gonec.user.v1.ConnectionState
+	36, // 1: gonec.user.v1.ListenReply.message:type_name -> gonec.shared.v1.Message
+	5,  // 2: gonec.user.v1.ListenReply.event:type_name -> gonec.user.v1.ConnectionEvent
+	37, // 3: gonec.user.v1.EventsReply.event:type_name -> gonec.shared.v1.Event
+	5,  // 4: gonec.user.v1.EventsReply.connection:type_name -> gonec.user.v1.ConnectionEvent
+	38, // 5: gonec.user.v1.EditMessageReply.edit:type_name -> gonec.shared.v1.MessageEdit
+	39, // 6: gonec.user.v1.DeleteMessageReply.delete:type_name -> gonec.shared.v1.MessageDelete
+	40, // 7: gonec.user.v1.ReactReply.reaction:type_name -> gonec.shared.v1.Reaction
+	36, // 8: gonec.user.v1.ListThreadReply.messages:type_name -> gonec.shared.v1.Message
+	1,  // 9: gonec.user.v1.StoredMessage.direction:type_name -> gonec.user.v1.MessageDirection
+	41, // 10: gonec.user.v1.StoredMessage.created_at:type_name -> google.protobuf.Timestamp
+	41, // 11: gonec.user.v1.StoredMessage.edited_at:type_name -> google.protobuf.Timestamp
+	41, // 12: gonec.user.v1.StoredMessage.deleted_at:type_name -> google.protobuf.Timestamp
+	18, // 13: gonec.user.v1.StoredMessage.reactions:type_name -> gonec.user.v1.ReactionCount
+	17, // 14: gonec.user.v1.ListMessagesReply.messages:type_name -> gonec.user.v1.StoredMessage
+	17, // 15: gonec.user.v1.SearchMessagesReply.messages:type_name -> gonec.user.v1.StoredMessage
+	42, // 16: gonec.user.v1.LookupUserReply.user:type_name -> gonec.shared.v1.UserIdentity
+	43, // 17: gonec.user.v1.GetPresenceReply.presences:type_name -> gonec.shared.v1.Presence
+	44, // 18: gonec.user.v1.BlockUserReply.block:type_name -> gonec.shared.v1.Block
+	44, // 19: gonec.user.v1.ListBlocksReply.blocks:type_name -> gonec.shared.v1.Block
+	2,  // 20: gonec.user.v1.ChatService.Send:input_type -> gonec.user.v1.SendRequest
+	4,  // 21: gonec.user.v1.ChatService.Listen:input_type -> gonec.user.v1.ListenRequest
+	7,  // 22: gonec.user.v1.ChatService.Events:input_type -> gonec.user.v1.EventsRequest
+	9,  // 23: gonec.user.v1.ChatService.EditMessage:input_type -> gonec.user.v1.EditMessageRequest
+	11, // 24: gonec.user.v1.ChatService.DeleteMessage:input_type -> gonec.user.v1.DeleteMessageRequest
+	13, // 25: gonec.user.v1.ChatService.React:input_type -> gonec.user.v1.ReactRequest
+	15, // 26: gonec.user.v1.ChatService.ListThread:input_type -> gonec.user.v1.ListThreadRequest
+	19, // 27: gonec.user.v1.ChatService.ListMessages:input_type -> gonec.user.v1.ListMessagesRequest
+	21, // 28: gonec.user.v1.ChatService.SearchMessages:input_type -> gonec.user.v1.SearchMessagesRequest
+	23, // 29: gonec.user.v1.ChatService.LookupUser:input_type -> gonec.user.v1.LookupUserRequest
+	25, // 30: gonec.user.v1.ChatService.GetPresence:input_type -> gonec.user.v1.GetPresenceRequest
+	27, // 31: gonec.user.v1.ChatService.WatchPresence:input_type -> gonec.user.v1.WatchPresenceRequest
+	28, // 32: gonec.user.v1.ChatService.SetPresenceHidden:input_type -> gonec.user.v1.SetPresenceHiddenRequest
+	30, // 33: gonec.user.v1.ChatService.BlockUser:input_type -> gonec.user.v1.BlockUserRequest
+	32, // 34: gonec.user.v1.ChatService.UnblockUser:input_type -> gonec.user.v1.UnblockUserRequest
+	34, // 35: gonec.user.v1.ChatService.ListBlocks:input_type -> gonec.user.v1.ListBlocksRequest
+	3,  // 36: gonec.user.v1.ChatService.Send:output_type -> gonec.user.v1.SendReply
+	6,  // 37: gonec.user.v1.ChatService.Listen:output_type -> gonec.user.v1.ListenReply
+	8,  // 38: gonec.user.v1.ChatService.Events:output_type -> gonec.user.v1.EventsReply
+	10, // 39: gonec.user.v1.ChatService.EditMessage:output_type -> gonec.user.v1.EditMessageReply
+	12, // 40: gonec.user.v1.ChatService.DeleteMessage:output_type -> gonec.user.v1.DeleteMessageReply
+	14, // 41: gonec.user.v1.ChatService.React:output_type -> gonec.user.v1.ReactReply
+	16, // 42: gonec.user.v1.ChatService.ListThread:output_type -> gonec.user.v1.ListThreadReply
+	20, // 43: gonec.user.v1.ChatService.ListMessages:output_type -> gonec.user.v1.ListMessagesReply
+	22, // 44: gonec.user.v1.ChatService.SearchMessages:output_type -> gonec.user.v1.SearchMessagesReply
+	24, // 45: gonec.user.v1.ChatService.LookupUser:output_type -> gonec.user.v1.LookupUserReply
+	26, // 46: gonec.user.v1.ChatService.GetPresence:output_type -> gonec.user.v1.GetPresenceReply
+	43, // 47: gonec.user.v1.ChatService.WatchPresence:output_type -> gonec.shared.v1.Presence
+	29, // 48: gonec.user.v1.ChatService.SetPresenceHidden:output_type -> gonec.user.v1.SetPresenceHiddenReply
+	31, // 49: gonec.user.v1.ChatService.BlockUser:output_type -> gonec.user.v1.BlockUserReply
+	33, // 50: gonec.user.v1.ChatService.UnblockUser:output_type -> gonec.user.v1.UnblockUserReply
+	35, // 51: gonec.user.v1.ChatService.ListBlocks:output_type -> gonec.user.v1.ListBlocksReply
+	36, // [36:52] is the sub-list for method output_type
+	20, // [20:36] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_user_chat_proto_init() }
+func file_user_chat_proto_init() {
+	if File_user_chat_proto != nil {
+		return
+	}
+	file_user_chat_proto_msgTypes[4].OneofWrappers = []any{
+		(*ListenReply_Message)(nil),
+		(*ListenReply_Event)(nil),
+	}
+	file_user_chat_proto_msgTypes[6].OneofWrappers = []any{
+		(*EventsReply_Event)(nil),
+		(*EventsReply_Connection)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_chat_proto_rawDesc), len(file_user_chat_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   3,
+			NumEnums:      2,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_user_chat_proto_goTypes,
 		DependencyIndexes: file_user_chat_proto_depIdxs,
+		EnumInfos:         file_user_chat_proto_enumTypes,
 		MessageInfos:      file_user_chat_proto_msgTypes,
 	}.Build()
 	File_user_chat_proto = out.File
@@ -0,0 +1,790 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v7.34.1
+// source: user/pin.proto
+
+package user
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ConflictPolicy int32
+
+const (
+	ConflictPolicy_CONFLICT_POLICY_FAIL_UNSPECIFIED ConflictPolicy = 0
+	ConflictPolicy_CONFLICT_POLICY_SKIP             ConflictPolicy = 1
+	ConflictPolicy_CONFLICT_POLICY_OVERWRITE        ConflictPolicy = 2
+	ConflictPolicy_CONFLICT_POLICY_RENAME           ConflictPolicy = 3
+)
+
+// Enum value maps for ConflictPolicy.
+var (
+	ConflictPolicy_name = map[int32]string{
+		0: "CONFLICT_POLICY_FAIL_UNSPECIFIED",
+		1: "CONFLICT_POLICY_SKIP",
+		2: "CONFLICT_POLICY_OVERWRITE",
+		3: "CONFLICT_POLICY_RENAME",
+	}
+	ConflictPolicy_value = map[string]int32{
+		"CONFLICT_POLICY_FAIL_UNSPECIFIED": 0,
+		"CONFLICT_POLICY_SKIP":             1,
+		"CONFLICT_POLICY_OVERWRITE":        2,
+		"CONFLICT_POLICY_RENAME":           3,
+	}
+)
+
+func (x ConflictPolicy) Enum() *ConflictPolicy {
+	p := new(ConflictPolicy)
+	*p = x
+	return p
+}
+
+func (x ConflictPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConflictPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_user_pin_proto_enumTypes[0].Descriptor()
+}
+
+func (ConflictPolicy) Type() protoreflect.EnumType {
+	return &file_user_pin_proto_enumTypes[0]
+}
+
+func (x ConflictPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConflictPolicy.Descriptor instead.
+func (ConflictPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_user_pin_proto_rawDescGZIP(), []int{0}
+}
+
+type Pin struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId      string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	ServerAddress     string                 `protobuf:"bytes,2,opt,name=server_address,json=serverAddress,proto3" json:"server_address,omitempty"`
+	ServerFingerprint string                 `protobuf:"bytes,3,opt,name=server_fingerprint,json=serverFingerprint,proto3" json:"server_fingerprint,omitempty"`
+	UserId            string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserName          string                 `protobuf:"bytes,5,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Pin) Reset() {
+	*x = Pin{}
+	mi := &file_user_pin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pin) ProtoMessage() {}
+
+func (x *Pin) ProtoReflect() protoreflect.Message {
+	mi := &file_user_pin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pin.ProtoReflect.Descriptor instead.
+func (*Pin) Descriptor() ([]byte, []int) {
+	return file_user_pin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Pin) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *Pin) GetServerAddress() string {
+	if x != nil {
+		return x.ServerAddress
+	}
+	return ""
+}
+
+func (x *Pin) GetServerFingerprint() string {
+	if x != nil {
+		return x.ServerFingerprint
+	}
+	return ""
+}
+
+func (x *Pin) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Pin) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+type ListPinsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPinsRequest) Reset() {
+	*x = ListPinsRequest{}
+	mi := &file_user_pin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinsRequest) ProtoMessage() {}
+
+func (x *ListPinsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_pin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinsRequest.ProtoReflect.Descriptor instead.
+func (*ListPinsRequest) Descriptor() ([]byte, []int) {
+	return file_user_pin_proto_rawDescGZIP(), []int{1}
+}
+
+type ListPinsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pins          []*Pin                 `protobuf:"bytes,1,rep,name=pins,proto3" json:"pins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPinsReply) Reset() {
+	*x = ListPinsReply{}
+	mi := &file_user_pin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinsReply) ProtoMessage() {}
+
+func (x *ListPinsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_pin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinsReply.ProtoReflect.Descriptor instead.
+func (*ListPinsReply) Descriptor() ([]byte, []int) {
+	return file_user_pin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListPinsReply) GetPins() []*Pin {
+	if x != nil {
+		return x.Pins
+	}
+	return nil
+}
+
+type RenamePinRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId    string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	NewConnectionId string                 `protobuf:"bytes,2,opt,name=new_connection_id,json=newConnectionId,proto3" json:"new_connection_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RenamePinRequest) Reset() {
+	*x = RenamePinRequest{}
+	mi := &file_user_pin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenamePinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenamePinRequest) ProtoMessage() {}
+
+func (x *RenamePinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_pin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenamePinRequest.ProtoReflect.Descriptor instead.
+func (*RenamePinRequest) Descriptor() ([]byte, []int) {
+	return file_user_pin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RenamePinRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *RenamePinRequest) GetNewConnectionId() string {
+	if x != nil {
+		return x.NewConnectionId
+	}
+	return ""
+}
+
+type RenamePinReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenamePinReply) Reset() {
+	*x = RenamePinReply{}
+	mi := &file_user_pin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenamePinReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenamePinReply) ProtoMessage() {}
+
+func (x *RenamePinReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_pin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenamePinReply.ProtoReflect.Descriptor instead.
+func (*RenamePinReply) Descriptor() ([]byte, []int) {
+	return file_user_pin_proto_rawDescGZIP(), []int{4}
+}
+
+type DeletePinRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePinRequest) Reset() {
+	*x = DeletePinRequest{}
+	mi := &file_user_pin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePinRequest) ProtoMessage() {}
+
+func (x *DeletePinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_pin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePinRequest.ProtoReflect.Descriptor instead.
+func (*DeletePinRequest) Descriptor() ([]byte, []int) {
+	return file_user_pin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeletePinRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+type DeletePinReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePinReply) Reset() {
+	*x = DeletePinReply{}
+	mi := &file_user_pin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePinReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePinReply) ProtoMessage() {}
+
+func (x *DeletePinReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_pin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePinReply.ProtoReflect.Descriptor instead.
+func (*DeletePinReply) Descriptor() ([]byte, []int) {
+	return file_user_pin_proto_rawDescGZIP(), []int{6}
+}
+
+type ExportPinsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionIds []string               `protobuf:"bytes,1,rep,name=connection_ids,json=connectionIds,proto3" json:"connection_ids,omitempty"`
+	Passphrase    string                 `protobuf:"bytes,2,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportPinsRequest) Reset() {
+	*x = ExportPinsRequest{}
+	mi := &file_user_pin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportPinsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportPinsRequest) ProtoMessage() {}
+
+func (x *ExportPinsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_pin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportPinsRequest.ProtoReflect.Descriptor instead.
+func (*ExportPinsRequest) Descriptor() ([]byte, []int) {
+	return file_user_pin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExportPinsRequest) GetConnectionIds() []string {
+	if x != nil {
+		return x.ConnectionIds
+	}
+	return nil
+}
+
+func (x *ExportPinsRequest) GetPassphrase() string {
+	if x != nil {
+		return x.Passphrase
+	}
+	return ""
+}
+
+type ExportPinsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundle        []byte                 `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportPinsReply) Reset() {
+	*x = ExportPinsReply{}
+	mi := &file_user_pin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportPinsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportPinsReply) ProtoMessage() {}
+
+func (x *ExportPinsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_pin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportPinsReply.ProtoReflect.Descriptor instead.
+func (*ExportPinsReply) Descriptor() ([]byte, []int) {
+	return file_user_pin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ExportPinsReply) GetBundle() []byte {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type ImportPinsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundle        []byte                 `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	Passphrase    string                 `protobuf:"bytes,2,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	OnConflict    ConflictPolicy         `protobuf:"varint,3,opt,name=on_conflict,json=onConflict,proto3,enum=gonec.user.v1.ConflictPolicy" json:"on_conflict,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportPinsRequest) Reset() {
+	*x = ImportPinsRequest{}
+	mi := &file_user_pin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportPinsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportPinsRequest) ProtoMessage() {}
+
+func (x *ImportPinsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_pin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportPinsRequest.ProtoReflect.Descriptor instead.
+func (*ImportPinsRequest) Descriptor() ([]byte, []int) {
+	return file_user_pin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ImportPinsRequest) GetBundle() []byte {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+func (x *ImportPinsRequest) GetPassphrase() string {
+	if x != nil {
+		return x.Passphrase
+	}
+	return ""
+}
+
+func (x *ImportPinsRequest) GetOnConflict() ConflictPolicy {
+	if x != nil {
+		return x.OnConflict
+	}
+	return ConflictPolicy_CONFLICT_POLICY_FAIL_UNSPECIFIED
+}
+
+type ImportedPin struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	ImportedAs    string                 `protobuf:"bytes,2,opt,name=imported_as,json=importedAs,proto3" json:"imported_as,omitempty"`
+	Skipped       bool                   `protobuf:"varint,3,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportedPin) Reset() {
+	*x = ImportedPin{}
+	mi := &file_user_pin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportedPin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportedPin) ProtoMessage() {}
+
+func (x *ImportedPin) ProtoReflect() protoreflect.Message {
+	mi := &file_user_pin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportedPin.ProtoReflect.Descriptor instead.
+func (*ImportedPin) Descriptor() ([]byte, []int) {
+	return file_user_pin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ImportedPin) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *ImportedPin) GetImportedAs() string {
+	if x != nil {
+		return x.ImportedAs
+	}
+	return ""
+}
+
+func (x *ImportedPin) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+type ImportPinsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pins          []*ImportedPin         `protobuf:"bytes,1,rep,name=pins,proto3" json:"pins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportPinsReply) Reset() {
+	*x = ImportPinsReply{}
+	mi := &file_user_pin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportPinsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportPinsReply) ProtoMessage() {}
+
+func (x *ImportPinsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_user_pin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportPinsReply.ProtoReflect.Descriptor instead.
+func (*ImportPinsReply) Descriptor() ([]byte, []int) {
+	return file_user_pin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ImportPinsReply) GetPins() []*ImportedPin {
+	if x != nil {
+		return x.Pins
+	}
+	return nil
+}
+
+var File_user_pin_proto protoreflect.FileDescriptor
+
+const file_user_pin_proto_rawDesc = "" +
+	"\n" +
+	"\x0euser/pin.proto\x12\rgonec.user.v1\"\xb6\x01\n" +
+	"\x03Pin\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12%\n" +
+	"\x0eserver_address\x18\x02 \x01(\tR\rserverAddress\x12-\n" +
+	"\x12server_fingerprint\x18\x03 \x01(\tR\x11serverFingerprint\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tuser_name\x18\x05 \x01(\tR\buserName\"\x11\n" +
+	"\x0fListPinsRequest\"7\n" +
+	"\rListPinsReply\x12&\n" +
+	"\x04pins\x18\x01 \x03(\v2\x12.gonec.user.v1.PinR\x04pins\"c\n" +
+	"\x10RenamePinRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12*\n" +
+	"\x11new_connection_id\x18\x02 \x01(\tR\x0fnewConnectionId\"\x10\n" +
+	"\x0eRenamePinReply\"7\n" +
+	"\x10DeletePinRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\"\x10\n" +
+	"\x0eDeletePinReply\"Z\n" +
+	"\x11ExportPinsRequest\x12%\n" +
+	"\x0econnection_ids\x18\x01 \x03(\tR\rconnectionIds\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x02 \x01(\tR\n" +
+	"passphrase\")\n" +
+	"\x0fExportPinsReply\x12\x16\n" +
+	"\x06bundle\x18\x01 \x01(\fR\x06bundle\"\x8b\x01\n" +
+	"\x11ImportPinsRequest\x12\x16\n" +
+	"\x06bundle\x18\x01 \x01(\fR\x06bundle\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x02 \x01(\tR\n" +
+	"passphrase\x12>\n" +
+	"\von_conflict\x18\x03 \x01(\x0e2\x1d.gonec.user.v1.ConflictPolicyR\n" +
+	"onConflict\"m\n" +
+	"\vImportedPin\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12\x1f\n" +
+	"\vimported_as\x18\x02 \x01(\tR\n" +
+	"importedAs\x12\x18\n" +
+	"\askipped\x18\x03 \x01(\bR\askipped\"A\n" +
+	"\x0fImportPinsReply\x12.\n" +
+	"\x04pins\x18\x01 \x03(\v2\x1a.gonec.user.v1.ImportedPinR\x04pins*\x8b\x01\n" +
+	"\x0eConflictPolicy\x12$\n" +
+	" CONFLICT_POLICY_FAIL_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14CONFLICT_POLICY_SKIP\x10\x01\x12\x1d\n" +
+	"\x19CONFLICT_POLICY_OVERWRITE\x10\x02\x12\x1a\n" +
+	"\x16CONFLICT_POLICY_RENAME\x10\x032\x90\x03\n" +
+	"\n" +
+	"PinService\x12H\n" +
+	"\bListPins\x12\x1e.gonec.user.v1.ListPinsRequest\x1a\x1c.gonec.user.v1.ListPinsReply\x12K\n" +
+	"\tRenamePin\x12\x1f.gonec.user.v1.RenamePinRequest\x1a\x1d.gonec.user.v1.RenamePinReply\x12K\n" +
+	"\tDeletePin\x12\x1f.gonec.user.v1.DeletePinRequest\x1a\x1d.gonec.user.v1.DeletePinReply\x12N\n" +
+	"\n" +
+	"ExportPins\x12 .gonec.user.v1.ExportPinsRequest\x1a\x1e.gonec.user.v1.ExportPinsReply\x12N\n" +
+	"\n" +
+	"ImportPins\x12 .gonec.user.v1.ImportPinsRequest\x1a\x1e.gonec.user.v1.ImportPinsReplyB&Z$github.com/charadev96/gonec/gen/userb\x06proto3"
+
+var (
+	file_user_pin_proto_rawDescOnce sync.Once
+	file_user_pin_proto_rawDescData []byte
+)
+
+func file_user_pin_proto_rawDescGZIP() []byte {
+	file_user_pin_proto_rawDescOnce.Do(func() {
+		file_user_pin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_user_pin_proto_rawDesc), len(file_user_pin_proto_rawDesc)))
+	})
+	return file_user_pin_proto_rawDescData
+}
+
+var file_user_pin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_user_pin_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_user_pin_proto_goTypes = []any{
+	(ConflictPolicy)(0),       // 0: gonec.user.v1.ConflictPolicy
+	(*Pin)(nil),               // 1: gonec.user.v1.Pin
+	(*ListPinsRequest)(nil),   // 2: gonec.user.v1.ListPinsRequest
+	(*ListPinsReply)(nil),     // 3: gonec.user.v1.ListPinsReply
+	(*RenamePinRequest)(nil),  // 4: gonec.user.v1.RenamePinRequest
+	(*RenamePinReply)(nil),    // 5: gonec.user.v1.RenamePinReply
+	(*DeletePinRequest)(nil),  // 6: gonec.user.v1.DeletePinRequest
+	(*DeletePinReply)(nil),    // 7: gonec.user.v1.DeletePinReply
+	(*ExportPinsRequest)(nil), // 8: gonec.user.v1.ExportPinsRequest
+	(*ExportPinsReply)(nil),   // 9: gonec.user.v1.ExportPinsReply
+	(*ImportPinsRequest)(nil), // 10: gonec.user.v1.ImportPinsRequest
+	(*ImportedPin)(nil),       // 11: gonec.user.v1.ImportedPin
+	(*ImportPinsReply)(nil),   // 12: gonec.user.v1.ImportPinsReply
+}
+var file_user_pin_proto_depIdxs = []int32{
+	1,  // 0: gonec.user.v1.ListPinsReply.pins:type_name -> gonec.user.v1.Pin
+	0,  // 1: gonec.user.v1.ImportPinsRequest.on_conflict:type_name -> gonec.user.v1.ConflictPolicy
+	11, // 2: gonec.user.v1.ImportPinsReply.pins:type_name -> gonec.user.v1.ImportedPin
+	2,  // 3: gonec.user.v1.PinService.ListPins:input_type -> gonec.user.v1.ListPinsRequest
+	4,  // 4: gonec.user.v1.PinService.RenamePin:input_type -> gonec.user.v1.RenamePinRequest
+	6,  // 5: gonec.user.v1.PinService.DeletePin:input_type -> gonec.user.v1.DeletePinRequest
+	8,  // 6: gonec.user.v1.PinService.ExportPins:input_type -> gonec.user.v1.ExportPinsRequest
+	10, // 7: gonec.user.v1.PinService.ImportPins:input_type -> gonec.user.v1.ImportPinsRequest
+	3,  // 8: gonec.user.v1.PinService.ListPins:output_type -> gonec.user.v1.ListPinsReply
+	5,  // 9: gonec.user.v1.PinService.RenamePin:output_type -> gonec.user.v1.RenamePinReply
+	7,  // 10: gonec.user.v1.PinService.DeletePin:output_type -> gonec.user.v1.DeletePinReply
+	9,  // 11: gonec.user.v1.PinService.ExportPins:output_type -> gonec.user.v1.ExportPinsReply
+	12, // 12: gonec.user.v1.PinService.ImportPins:output_type -> gonec.user.v1.ImportPinsReply
+	8,  // [8:13] is the sub-list for method output_type
+	3,  // [3:8] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_user_pin_proto_init() }
+func file_user_pin_proto_init() {
+	if File_user_pin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_pin_proto_rawDesc), len(file_user_pin_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_user_pin_proto_goTypes,
+		DependencyIndexes: file_user_pin_proto_depIdxs,
+		EnumInfos:         file_user_pin_proto_enumTypes,
+		MessageInfos:      file_user_pin_proto_msgTypes,
+	}.Build()
+	File_user_pin_proto = out.File
+	file_user_pin_proto_goTypes = nil
+	file_user_pin_proto_depIdxs = nil
+}
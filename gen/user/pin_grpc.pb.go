@@ -0,0 +1,273 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.1
+// - protoc             v7.34.1
+// source: user/pin.proto
+
+package user
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PinService_ListPins_FullMethodName   = "/gonec.user.v1.PinService/ListPins"
+	PinService_RenamePin_FullMethodName  = "/gonec.user.v1.PinService/RenamePin"
+	PinService_DeletePin_FullMethodName  = "/gonec.user.v1.PinService/DeletePin"
+	PinService_ExportPins_FullMethodName = "/gonec.user.v1.PinService/ExportPins"
+	PinService_ImportPins_FullMethodName = "/gonec.user.v1.PinService/ImportPins"
+)
+
+// PinServiceClient is the client API for PinService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PinServiceClient interface {
+	ListPins(ctx context.Context, in *ListPinsRequest, opts ...grpc.CallOption) (*ListPinsReply, error)
+	RenamePin(ctx context.Context, in *RenamePinRequest, opts ...grpc.CallOption) (*RenamePinReply, error)
+	DeletePin(ctx context.Context, in *DeletePinRequest, opts ...grpc.CallOption) (*DeletePinReply, error)
+	ExportPins(ctx context.Context, in *ExportPinsRequest, opts ...grpc.CallOption) (*ExportPinsReply, error)
+	ImportPins(ctx context.Context, in *ImportPinsRequest, opts ...grpc.CallOption) (*ImportPinsReply, error)
+}
+
+type pinServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPinServiceClient(cc grpc.ClientConnInterface) PinServiceClient {
+	return &pinServiceClient{cc}
+}
+
+func (c *pinServiceClient) ListPins(ctx context.Context, in *ListPinsRequest, opts ...grpc.CallOption) (*ListPinsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPinsReply)
+	err := c.cc.Invoke(ctx, PinService_ListPins_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pinServiceClient) RenamePin(ctx context.Context, in *RenamePinRequest, opts ...grpc.CallOption) (*RenamePinReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenamePinReply)
+	err := c.cc.Invoke(ctx, PinService_RenamePin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pinServiceClient) DeletePin(ctx context.Context, in *DeletePinRequest, opts ...grpc.CallOption) (*DeletePinReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletePinReply)
+	err := c.cc.Invoke(ctx, PinService_DeletePin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pinServiceClient) ExportPins(ctx context.Context, in *ExportPinsRequest, opts ...grpc.CallOption) (*ExportPinsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportPinsReply)
+	err := c.cc.Invoke(ctx, PinService_ExportPins_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pinServiceClient) ImportPins(ctx context.Context, in *ImportPinsRequest, opts ...grpc.CallOption) (*ImportPinsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportPinsReply)
+	err := c.cc.Invoke(ctx, PinService_ImportPins_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PinServiceServer is the server API for PinService service.
+// All implementations must embed UnimplementedPinServiceServer
+// for forward compatibility.
+type PinServiceServer interface {
+	ListPins(context.Context, *ListPinsRequest) (*ListPinsReply, error)
+	RenamePin(context.Context, *RenamePinRequest) (*RenamePinReply, error)
+	DeletePin(context.Context, *DeletePinRequest) (*DeletePinReply, error)
+	ExportPins(context.Context, *ExportPinsRequest) (*ExportPinsReply, error)
+	ImportPins(context.Context, *ImportPinsRequest) (*ImportPinsReply, error)
+	mustEmbedUnimplementedPinServiceServer()
+}
+
+// UnimplementedPinServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPinServiceServer struct{}
+
+func (UnimplementedPinServiceServer) ListPins(context.Context, *ListPinsRequest) (*ListPinsReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPins not implemented")
+}
+func (UnimplementedPinServiceServer) RenamePin(context.Context, *RenamePinRequest) (*RenamePinReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenamePin not implemented")
+}
+func (UnimplementedPinServiceServer) DeletePin(context.Context, *DeletePinRequest) (*DeletePinReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeletePin not implemented")
+}
+func (UnimplementedPinServiceServer) ExportPins(context.Context, *ExportPinsRequest) (*ExportPinsReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportPins not implemented")
+}
+func (UnimplementedPinServiceServer) ImportPins(context.Context, *ImportPinsRequest) (*ImportPinsReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportPins not implemented")
+}
+func (UnimplementedPinServiceServer) mustEmbedUnimplementedPinServiceServer() {}
+func (UnimplementedPinServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafePinServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PinServiceServer will
+// result in compilation errors.
+type UnsafePinServiceServer interface {
+	mustEmbedUnimplementedPinServiceServer()
+}
+
+func RegisterPinServiceServer(s grpc.ServiceRegistrar, srv PinServiceServer) {
+	// If the following call panics, it indicates UnimplementedPinServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PinService_ServiceDesc, srv)
+}
+
+func _PinService_ListPins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPinsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PinServiceServer).ListPins(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PinService_ListPins_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PinServiceServer).ListPins(ctx, req.(*ListPinsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PinService_RenamePin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenamePinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PinServiceServer).RenamePin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PinService_RenamePin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PinServiceServer).RenamePin(ctx, req.(*RenamePinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PinService_DeletePin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PinServiceServer).DeletePin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PinService_DeletePin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PinServiceServer).DeletePin(ctx, req.(*DeletePinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PinService_ExportPins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportPinsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PinServiceServer).ExportPins(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PinService_ExportPins_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PinServiceServer).ExportPins(ctx, req.(*ExportPinsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PinService_ImportPins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportPinsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PinServiceServer).ImportPins(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PinService_ImportPins_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PinServiceServer).ImportPins(ctx, req.(*ImportPinsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PinService_ServiceDesc is the grpc.ServiceDesc for PinService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PinService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gonec.user.v1.PinService",
+	HandlerType: (*PinServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListPins",
+			Handler:    _PinService_ListPins_Handler,
+		},
+		{
+			MethodName: "RenamePin",
+			Handler:    _PinService_RenamePin_Handler,
+		},
+		{
+			MethodName: "DeletePin",
+			Handler:    _PinService_DeletePin_Handler,
+		},
+		{
+			MethodName: "ExportPins",
+			Handler:    _PinService_ExportPins_Handler,
+		},
+		{
+			MethodName: "ImportPins",
+			Handler:    _PinService_ImportPins_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "user/pin.proto",
+}
@@ -6,6 +6,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
@@ -14,6 +15,8 @@ import (
 	userpb "github.com/charadev96/gonec/gen/user"
 	user "github.com/charadev96/gonec/internal/client/handler/user"
 	"github.com/charadev96/gonec/internal/client/service"
+	"github.com/charadev96/gonec/internal/shared/handler"
+	"github.com/charadev96/gonec/internal/shared/log"
 )
 
 type Config struct {
@@ -24,17 +27,27 @@ type Config struct {
 type Client struct {
 	cfg Config
 
-	auth *service.AuthService
-	chat *service.ChatService
+	auth        *service.AuthService
+	chat        *service.ChatService
+	pins        *service.PinService
+	attachments *service.AttachmentService
 }
 
-func New(cfg Config, auth *service.AuthService, chat *service.ChatService) *Client {
+func New(
+	cfg Config,
+	auth *service.AuthService,
+	chat *service.ChatService,
+	pins *service.PinService,
+	attachments *service.AttachmentService,
+) *Client {
 	l := zerolog.Nop()
 	s := &Client{
 		cfg: cfg,
 
-		auth: auth,
-		chat: chat,
+		auth:        auth,
+		chat:        chat,
+		pins:        pins,
+		attachments: attachments,
 	}
 	if s.cfg.Logger == nil {
 		s.cfg.Logger = &l
@@ -51,7 +64,26 @@ func (c *Client) ServeUser(ctx context.Context) error {
 		Str("address", c.cfg.Addr).
 		Msg("started client")
 
+	logger := log.NewInterceptor(*c.cfg.Logger)
+	opts := []logging.Option{
+		logging.WithLogOnEvents(logging.StartCall, logging.FinishCall),
+		logging.WithFieldsFromContext(log.IdentityFields),
+	}
 	inst := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			handler.UnaryRecoveryInterceptor(*c.cfg.Logger),
+			log.UnaryRequestIDInterceptor(*c.cfg.Logger),
+			log.UnaryIdentityInterceptor(c.connectionIdentity),
+			logging.UnaryServerInterceptor(logger, opts...),
+			handler.UnaryErrorInterceptor(*c.cfg.Logger),
+		),
+		grpc.ChainStreamInterceptor(
+			handler.StreamRecoveryInterceptor(*c.cfg.Logger),
+			log.StreamRequestIDInterceptor(*c.cfg.Logger),
+			log.StreamIdentityInterceptor(c.connectionIdentity),
+			logging.StreamServerInterceptor(logger, opts...),
+			handler.StreamErrorInterceptor(*c.cfg.Logger),
+		),
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			Time:    30 * time.Second,
 			Timeout: 10 * time.Second,
@@ -59,6 +91,8 @@ func (c *Client) ServeUser(ctx context.Context) error {
 	)
 	userpb.RegisterAuthServiceServer(inst, user.NewAuthHandler(c.auth))
 	userpb.RegisterChatServiceServer(inst, user.NewChatHandler(ctx, c.chat))
+	userpb.RegisterPinServiceServer(inst, user.NewPinHandler(c.pins))
+	userpb.RegisterAttachmentServiceServer(inst, user.NewAttachmentHandler(ctx, c.attachments))
 
 	reflection.Register(inst)
 
@@ -70,3 +104,17 @@ func (c *Client) ServeUser(ctx context.Context) error {
 
 	return inst.Serve(ln)
 }
+
+// connectionIdentity takes the user from the pin of the connection the
+// request is made on.
+func (c *Client) connectionIdentity(req any) string {
+	r, ok := req.(interface{ GetConnectionId() string })
+	if !ok {
+		return ""
+	}
+	pin, err := c.auth.Pin(r.GetConnectionId())
+	if err != nil {
+		return ""
+	}
+	return pin.User.ID.String()
+}
@@ -0,0 +1,27 @@
+package domain
+
+// ConflictPolicy decides what an import does with a pin whose ID is
+// already taken.
+type ConflictPolicy int
+
+const (
+	ConflictFail ConflictPolicy = iota
+	ConflictSkip
+	ConflictOverwrite
+	ConflictRename
+)
+
+// ImportResult tells under which ID a pin from a bundle was imported, if
+// it was.
+type ImportResult struct {
+	ConnID     string
+	ImportedAs string
+	Skipped    bool
+}
+
+// PinBundleCodec seals pins, private keys included, into a portable bundle
+// encrypted with a passphrase.
+type PinBundleCodec interface {
+	Seal(pins []ConnPin, passphrase string) ([]byte, error)
+	Open(bundle []byte, passphrase string) ([]ConnPin, error)
+}
@@ -1,12 +1,15 @@
 package domain
 
 import (
-	"errors"
+	shared "github.com/charadev96/gonec/internal/shared/domain"
 )
 
 var (
-	ErrConn       = errors.New("already connected")
-	ErrNoConn     = errors.New("no active connection")
-	ErrLoggedIn   = errors.New("already logged in")
-	ErrNoLoggedIn = errors.New("not logged in")
+	ErrConn       = shared.NewError(shared.ErrFailedPrecondition, "already connected")
+	ErrNoConn     = shared.NewError(shared.ErrFailedPrecondition, "no active connection")
+	ErrConnActive = shared.NewError(shared.ErrFailedPrecondition, "connection is active")
+	ErrLoggedIn   = shared.NewError(shared.ErrFailedPrecondition, "already logged in")
+	ErrNoLoggedIn = shared.NewError(shared.ErrFailedPrecondition, "not logged in")
+	ErrUntrusted  = shared.NewError(shared.ErrFailedPrecondition, "server key not trusted")
+	ErrLocked     = shared.NewError(shared.ErrFailedPrecondition, "private keys are locked")
 )
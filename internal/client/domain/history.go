@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+)
+
+type Direction int
+
+const (
+	DirectionSent Direction = iota + 1
+	DirectionReceived
+)
+
+// StoredMessage is a message as kept in the local history of a connection.
+// Peer is the other party of the conversation, whichever way the message
+// went. MessageID is the ID the server assigned, if it did; deleted messages
+// are kept as tombstones with no content. Replies name the server IDs of the
+// message they answer and of their thread root.
+type StoredMessage struct {
+	ID        int64
+	ConnID    string
+	Peer      uuid.UUID
+	Direction Direction
+	Content   string
+	CreatedAt time.Time
+
+	MessageID uuid.UUID
+	EditedAt  time.Time
+	DeletedAt time.Time
+	Reactions []shared.ReactionCount
+
+	ReplyTo  uuid.UUID
+	ThreadID uuid.UUID
+}
+
+// MessageListQuery selects the messages of a conversation, newest first,
+// starting before Cursor unless it is zero.
+type MessageListQuery struct {
+	ConnID string
+	Peer   uuid.UUID
+	Limit  int
+	Cursor int64
+}
+
+// MessageSearchQuery selects the messages of a connection matching every
+// word of Text, newest first, starting before Cursor unless it is zero.
+type MessageSearchQuery struct {
+	ConnID string
+	Text   string
+	Limit  int
+	Cursor int64
+}
+
+type MessageList struct {
+	Messages []StoredMessage
+	Cursor   int64
+}
+
+type MessageRepository interface {
+	Save(ctx context.Context, m StoredMessage) (int64, error)
+	List(ctx context.Context, q MessageListQuery) (MessageList, error)
+	Search(ctx context.Context, q MessageSearchQuery) (MessageList, error)
+	// Edit, Delete and React apply to the message with the server ID of the change
+	// in the history of connID, and fail with ErrNotExist if there is none.
+	Edit(ctx context.Context, connID string, e shared.MessageEdit) error
+	Delete(ctx context.Context, connID string, d shared.MessageDelete) error
+	React(ctx context.Context, connID string, r shared.Reaction) error
+	RenameConn(ctx context.Context, from, to string) error
+}
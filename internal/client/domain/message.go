@@ -0,0 +1,37 @@
+package domain
+
+import (
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+)
+
+type ConnState int
+
+const (
+	ConnReconnecting ConnState = iota + 1
+	ConnConnected
+	ConnDisconnected
+)
+
+// ConnEvent reports a change in the state of a connection, with the error
+// that caused it and the reconnect attempt it belongs to, if any.
+type ConnEvent struct {
+	State   ConnState
+	Attempt int
+	Err     error
+}
+
+// ConnMessage is a message received on the connection with ConnID, or an
+// event about that connection if Event is set.
+type ConnMessage struct {
+	ConnID  string
+	Message shared.Message
+	Event   *ConnEvent
+}
+
+// ConnUpdate is an event from the server on the connection with ConnID, or
+// an event about that connection if Conn is set.
+type ConnUpdate struct {
+	ConnID string
+	Event  shared.Event
+	Conn   *ConnEvent
+}
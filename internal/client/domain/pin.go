@@ -10,6 +10,7 @@ import (
 
 type UserPrivateIdentity struct {
 	ID         uuid.UUID
+	Name       string
 	PrivateKey ed25519.PrivateKey
 }
 
@@ -21,6 +22,13 @@ type ConnPin struct {
 
 type ConnPinRepository interface {
 	Get(id string) (ConnPin, error)
+	List() ([]ConnPin, error)
 	Set(id string, pin ConnPin) error
+	// Merge stores the pins fn returns under their IDs in a single write. fn
+	// is given the IDs already taken, under the same lock as the write.
+	Merge(fn func(taken map[string]bool) ([]ConnPin, error)) error
 	Delete(id string) error
+	// Rename moves the pin from to the unused ID to in a single write.
+	Rename(from, to string) error
+	Unlock(passphrase string) error
 }
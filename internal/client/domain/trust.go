@@ -0,0 +1,11 @@
+package domain
+
+import (
+	"crypto/ed25519"
+)
+
+type PendingTrust struct {
+	ConnID      string
+	PublicKey   ed25519.PublicKey
+	Fingerprint string
+}
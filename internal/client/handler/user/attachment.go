@@ -0,0 +1,113 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+
+	userpb "github.com/charadev96/gonec/gen/user"
+	"github.com/charadev96/gonec/internal/client/service"
+	"github.com/charadev96/gonec/internal/shared/handler"
+	pb "github.com/charadev96/gonec/internal/shared/pb"
+)
+
+const attachmentChunkSize = 64 << 10
+
+type AttachmentHandler struct {
+	userpb.UnimplementedAttachmentServiceServer
+
+	ctx     context.Context
+	service *service.AttachmentService
+}
+
+func NewAttachmentHandler(ctx context.Context, s *service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{
+		ctx:     ctx,
+		service: s,
+	}
+}
+
+func (h *AttachmentHandler) Upload(stream grpc.ClientStreamingServer[userpb.UploadRequest, userpb.UploadReply]) error {
+	if context.Cause(h.ctx) != nil {
+		return handler.ErrShutdown
+	}
+
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	open := req.GetOpen()
+	if open == nil {
+		return handler.ErrArg(errors.New("upload must start with open"))
+	}
+
+	a, err := h.service.Upload(stream.Context(), open.ConnectionId, open.MimeType, &uploadReader{stream: stream})
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(&userpb.UploadReply{Attachment: pb.AttachmentToPB(a)})
+}
+
+func (h *AttachmentHandler) Download(req *userpb.DownloadRequest, stream grpc.ServerStreamingServer[userpb.DownloadReply]) error {
+	if context.Cause(h.ctx) != nil {
+		return handler.ErrShutdown
+	}
+
+	id, err := uuid.Parse(req.AttachmentId)
+	if err != nil {
+		return handler.ErrArg(err)
+	}
+	a, r, err := h.service.Download(stream.Context(), req.ConnectionId, id)
+	if err != nil {
+		return err
+	}
+
+	err = stream.Send(&userpb.DownloadReply{
+		Payload: &userpb.DownloadReply_Attachment{Attachment: pb.AttachmentToPB(a)},
+	})
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, attachmentChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			err := stream.Send(&userpb.DownloadReply{
+				Payload: &userpb.DownloadReply_Chunk{Chunk: buf[:n]},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+type uploadReader struct {
+	stream grpc.ClientStreamingServer[userpb.UploadRequest, userpb.UploadReply]
+	buf    []byte
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if req.GetOpen() != nil {
+			return 0, handler.ErrArg(errors.New("upload is already open"))
+		}
+		r.buf = req.GetChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
@@ -9,8 +9,6 @@ import (
 	pb "github.com/charadev96/gonec/internal/shared/pb"
 )
 
-// TODO: Sanitize errors
-
 type AuthHandler struct {
 	userpb.UnimplementedAuthServiceServer
 	service *service.AuthService
@@ -27,7 +25,7 @@ func (h *AuthHandler) Register(ctx context.Context, req *userpb.RegisterRequest)
 	}
 	err = h.service.Register(ctx, req.ConnectionId, t)
 	if err != nil {
-		return nil, handler.ErrInternal(err)
+		return nil, err
 	}
 	return &userpb.RegisterReply{}, nil
 }
@@ -35,15 +33,44 @@ func (h *AuthHandler) Register(ctx context.Context, req *userpb.RegisterRequest)
 func (h *AuthHandler) Login(ctx context.Context, req *userpb.LoginRequest) (*userpb.LoginReply, error) {
 	err := h.service.Login(ctx, req.ConnectionId)
 	if err != nil {
-		return nil, handler.ErrInternal(err)
+		return nil, err
 	}
 	return &userpb.LoginReply{}, nil
 }
 
 func (h *AuthHandler) Logout(ctx context.Context, req *userpb.LogoutRequest) (*userpb.LogoutReply, error) {
-	err := h.service.Logout(ctx)
+	err := h.service.Logout(ctx, req.ConnectionId)
 	if err != nil {
-		return nil, handler.ErrInternal(err)
+		return nil, err
 	}
 	return &userpb.LogoutReply{}, nil
 }
+
+func (h *AuthHandler) Unlock(ctx context.Context, req *userpb.UnlockRequest) (*userpb.UnlockReply, error) {
+	if err := h.service.Unlock(req.Passphrase); err != nil {
+		return nil, err
+	}
+	return &userpb.UnlockReply{}, nil
+}
+
+func (h *AuthHandler) GetPendingTrust(ctx context.Context, req *userpb.GetPendingTrustRequest) (*userpb.GetPendingTrustReply, error) {
+	rep := &userpb.GetPendingTrustReply{}
+	for _, p := range h.service.PendingTrusts() {
+		rep.Trusts = append(rep.Trusts, pb.PendingTrustToPB(p))
+	}
+	return rep, nil
+}
+
+func (h *AuthHandler) AcceptTrust(ctx context.Context, req *userpb.AcceptTrustRequest) (*userpb.AcceptTrustReply, error) {
+	if err := h.service.AcceptTrust(req.ConnectionId, req.Fingerprint); err != nil {
+		return nil, err
+	}
+	return &userpb.AcceptTrustReply{}, nil
+}
+
+func (h *AuthHandler) RejectTrust(ctx context.Context, req *userpb.RejectTrustRequest) (*userpb.RejectTrustReply, error) {
+	if err := h.service.RejectTrust(req.ConnectionId); err != nil {
+		return nil, err
+	}
+	return &userpb.RejectTrustReply{}, nil
+}
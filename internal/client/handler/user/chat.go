@@ -8,13 +8,12 @@ import (
 
 	sharedpb "github.com/charadev96/gonec/gen/shared"
 	userpb "github.com/charadev96/gonec/gen/user"
+	client "github.com/charadev96/gonec/internal/client/domain"
 	"github.com/charadev96/gonec/internal/client/service"
 	"github.com/charadev96/gonec/internal/shared/handler"
 	pb "github.com/charadev96/gonec/internal/shared/pb"
 )
 
-// TODO: Sanitize errors
-
 type ChatHandler struct {
 	userpb.UnimplementedChatServiceServer
 
@@ -31,46 +30,309 @@ func NewChatHandler(ctx context.Context, s *service.ChatService) *ChatHandler {
 
 func (h *ChatHandler) Send(ctx context.Context, req *userpb.SendRequest) (*userpb.SendReply, error) {
 	if context.Cause(h.ctx) != nil {
-		return nil, handler.ErrInternal(h.ctx.Err())
+		return nil, handler.ErrShutdown
+	}
+
+	attachments, err := handler.ParseUUIDs(req.AttachmentIds...)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	replyTo, err := handler.ParseOptionalUUID(req.ReplyTo)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	msg, err := h.service.Send(ctx, req.ConnectionId, req.Recipient, req.Content, attachments, replyTo)
+	if err != nil {
+		return nil, err
+	}
+	reply := &userpb.SendReply{RecipientId: msg.Recipient.String()}
+	if msg.ID != uuid.Nil {
+		reply.MessageId = msg.ID.String()
+	}
+	if msg.ThreadID != uuid.Nil {
+		reply.ThreadId = msg.ThreadID.String()
+	}
+	return reply, nil
+}
+
+func (h *ChatHandler) EditMessage(ctx context.Context, req *userpb.EditMessageRequest) (*userpb.EditMessageReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	id, err := uuid.Parse(req.MessageId)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	edit, err := h.service.EditMessage(ctx, req.ConnectionId, id, req.Content)
+	if err != nil {
+		return nil, err
+	}
+	return &userpb.EditMessageReply{Edit: pb.MessageEditToPB(edit)}, nil
+}
+
+func (h *ChatHandler) DeleteMessage(ctx context.Context, req *userpb.DeleteMessageRequest) (*userpb.DeleteMessageReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	id, err := uuid.Parse(req.MessageId)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	del, err := h.service.DeleteMessage(ctx, req.ConnectionId, id)
+	if err != nil {
+		return nil, err
+	}
+	return &userpb.DeleteMessageReply{Delete: pb.MessageDeleteToPB(del)}, nil
+}
+
+func (h *ChatHandler) React(ctx context.Context, req *userpb.ReactRequest) (*userpb.ReactReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	id, err := uuid.Parse(req.MessageId)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	r, err := h.service.React(ctx, req.ConnectionId, id, req.Emoji, req.Remove)
+	if err != nil {
+		return nil, err
+	}
+	return &userpb.ReactReply{Reaction: pb.ReactionToPB(r)}, nil
+}
+
+func (h *ChatHandler) ListThread(ctx context.Context, req *userpb.ListThreadRequest) (*userpb.ListThreadReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	id, err := uuid.Parse(req.MessageId)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	after, err := handler.ParseOptionalUUID(req.Cursor)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	page, err := h.service.ListThread(ctx, req.ConnectionId, id, after, int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+	reply := &userpb.ListThreadReply{Messages: pb.MessagesToPB(page.Messages)}
+	if page.Cursor != uuid.Nil {
+		reply.Cursor = page.Cursor.String()
+	}
+	return reply, nil
+}
+
+func (h *ChatHandler) LookupUser(ctx context.Context, req *userpb.LookupUserRequest) (*userpb.LookupUserReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	user, err := h.service.LookupUser(ctx, req.ConnectionId, req.User)
+	if err != nil {
+		return nil, err
+	}
+	return &userpb.LookupUserReply{User: pb.UserIdentityToPB(user)}, nil
+}
+
+func (h *ChatHandler) Listen(req *userpb.ListenRequest, stream grpc.ServerStreamingServer[userpb.ListenReply]) error {
+	if context.Cause(h.ctx) != nil {
+		return handler.ErrShutdown
+	}
+
+	ln, err := h.service.Listen(stream.Context(), req.ConnectionIds...)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-h.ctx.Done():
+			return handler.ErrShutdown
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case pck := <-ln:
+			if pck.Err != nil {
+				return pck.Err
+			}
+			if err := stream.Send(pb.ConnMessageToPB(pck.Msg)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (h *ChatHandler) Events(req *userpb.EventsRequest, stream grpc.ServerStreamingServer[userpb.EventsReply]) error {
+	if context.Cause(h.ctx) != nil {
+		return handler.ErrShutdown
+	}
+
+	presence, err := handler.ParseUUIDs(req.PresenceUserIds...)
+	if err != nil {
+		return handler.ErrArg(err)
+	}
+	ln, err := h.service.Events(stream.Context(), presence, req.ConnectionIds...)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-h.ctx.Done():
+			return handler.ErrShutdown
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case pck := <-ln:
+			if pck.Err != nil {
+				return pck.Err
+			}
+			if err := stream.Send(pb.ConnUpdateToPB(pck.Msg)); err != nil {
+				return err
+			}
+		}
 	}
+}
 
-	toid, err := uuid.Parse(req.Recipient)
+func (h *ChatHandler) ListMessages(ctx context.Context, req *userpb.ListMessagesRequest) (*userpb.ListMessagesReply, error) {
+	peer, err := uuid.Parse(req.Peer)
 	if err != nil {
 		return nil, handler.ErrArg(err)
 	}
-	if err := h.service.Send(ctx, toid, req.Content); err != nil {
-		return nil, handler.ErrInternal(err)
+
+	list, err := h.service.History(ctx, client.MessageListQuery{
+		ConnID: req.ConnectionId,
+		Peer:   peer,
+		Limit:  int(req.Limit),
+		Cursor: req.Cursor,
+	})
+	if err != nil {
+		return nil, err
 	}
-	return &userpb.SendReply{}, nil
+
+	return &userpb.ListMessagesReply{
+		Messages: pb.StoredMessagesToPB(list.Messages),
+		Cursor:   list.Cursor,
+	}, nil
 }
 
-func (h *ChatHandler) Listen(req *userpb.ListenRequest, stream grpc.ServerStreamingServer[sharedpb.Message]) error {
+func (h *ChatHandler) SearchMessages(ctx context.Context, req *userpb.SearchMessagesRequest) (*userpb.SearchMessagesReply, error) {
+	list, err := h.service.Search(ctx, client.MessageSearchQuery{
+		ConnID: req.ConnectionId,
+		Text:   req.Text,
+		Limit:  int(req.Limit),
+		Cursor: req.Cursor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &userpb.SearchMessagesReply{
+		Messages: pb.StoredMessagesToPB(list.Messages),
+		Cursor:   list.Cursor,
+	}, nil
+}
+
+func (h *ChatHandler) GetPresence(ctx context.Context, req *userpb.GetPresenceRequest) (*userpb.GetPresenceReply, error) {
 	if context.Cause(h.ctx) != nil {
-		return handler.ErrInternal(h.ctx.Err())
+		return nil, handler.ErrShutdown
 	}
 
-	ln, err := h.service.Listen(stream.Context())
+	ids, err := handler.ParseUUIDs(req.UserIds...)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	ps, err := h.service.Presence(ctx, req.ConnectionId, ids)
 	if err != nil {
-		return handler.ErrInternal(err)
+		return nil, err
 	}
+	return &userpb.GetPresenceReply{Presences: pb.PresencesToPB(ps)}, nil
+}
 
-	go func() {
+func (h *ChatHandler) WatchPresence(req *userpb.WatchPresenceRequest, stream grpc.ServerStreamingServer[sharedpb.Presence]) error {
+	if context.Cause(h.ctx) != nil {
+		return handler.ErrShutdown
+	}
 
-	}()
+	ids, err := handler.ParseUUIDs(req.UserIds...)
+	if err != nil {
+		return handler.ErrArg(err)
+	}
+	ln, err := h.service.WatchPresence(stream.Context(), req.ConnectionId, ids)
+	if err != nil {
+		return err
+	}
 
 	for {
 		select {
 		case <-h.ctx.Done():
-			return handler.ErrInternal(h.ctx.Err())
+			return handler.ErrShutdown
 		case <-stream.Context().Done():
-			return handler.ErrInternal(stream.Context().Err())
+			return stream.Context().Err()
 		case pck := <-ln:
 			if pck.Err != nil {
-				return handler.ErrInternal(err)
+				return pck.Err
 			}
-			if err := stream.Send(pb.MessageToPB(pck.Msg)); err != nil {
-				return handler.ErrInternal(pck.Err)
+			if err := stream.Send(pb.PresenceToPB(pck.Msg)); err != nil {
+				return err
 			}
 		}
 	}
 }
+
+func (h *ChatHandler) SetPresenceHidden(ctx context.Context, req *userpb.SetPresenceHiddenRequest) (*userpb.SetPresenceHiddenReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	if err := h.service.SetPresenceHidden(ctx, req.ConnectionId, req.Hidden); err != nil {
+		return nil, err
+	}
+	return &userpb.SetPresenceHiddenReply{}, nil
+}
+
+func (h *ChatHandler) BlockUser(ctx context.Context, req *userpb.BlockUserRequest) (*userpb.BlockUserReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	b, err := h.service.BlockUser(ctx, req.ConnectionId, req.User)
+	if err != nil {
+		return nil, err
+	}
+	return &userpb.BlockUserReply{Block: pb.BlockToPB(b)}, nil
+}
+
+func (h *ChatHandler) UnblockUser(ctx context.Context, req *userpb.UnblockUserRequest) (*userpb.UnblockUserReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	id, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	if err := h.service.UnblockUser(ctx, req.ConnectionId, id); err != nil {
+		return nil, err
+	}
+	return &userpb.UnblockUserReply{}, nil
+}
+
+func (h *ChatHandler) ListBlocks(ctx context.Context, req *userpb.ListBlocksRequest) (*userpb.ListBlocksReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	bs, err := h.service.ListBlocks(ctx, req.ConnectionId)
+	if err != nil {
+		return nil, err
+	}
+	reply := &userpb.ListBlocksReply{Blocks: make([]*sharedpb.Block, 0, len(bs))}
+	for _, b := range bs {
+		reply.Blocks = append(reply.Blocks, pb.BlockToPB(b))
+	}
+	return reply, nil
+}
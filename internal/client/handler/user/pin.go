@@ -0,0 +1,64 @@
+package user
+
+import (
+	"context"
+
+	userpb "github.com/charadev96/gonec/gen/user"
+	"github.com/charadev96/gonec/internal/client/service"
+	pb "github.com/charadev96/gonec/internal/shared/pb"
+)
+
+type PinHandler struct {
+	userpb.UnimplementedPinServiceServer
+	service *service.PinService
+}
+
+func NewPinHandler(s *service.PinService) *PinHandler {
+	return &PinHandler{service: s}
+}
+
+func (h *PinHandler) ListPins(ctx context.Context, req *userpb.ListPinsRequest) (*userpb.ListPinsReply, error) {
+	pins, err := h.service.List()
+	if err != nil {
+		return nil, err
+	}
+	rep := &userpb.ListPinsReply{Pins: make([]*userpb.Pin, len(pins))}
+	for i, p := range pins {
+		rep.Pins[i] = pb.ConnPinToPB(p)
+	}
+	return rep, nil
+}
+
+func (h *PinHandler) RenamePin(ctx context.Context, req *userpb.RenamePinRequest) (*userpb.RenamePinReply, error) {
+	if err := h.service.Rename(ctx, req.ConnectionId, req.NewConnectionId); err != nil {
+		return nil, err
+	}
+	return &userpb.RenamePinReply{}, nil
+}
+
+func (h *PinHandler) DeletePin(ctx context.Context, req *userpb.DeletePinRequest) (*userpb.DeletePinReply, error) {
+	if err := h.service.Delete(req.ConnectionId); err != nil {
+		return nil, err
+	}
+	return &userpb.DeletePinReply{}, nil
+}
+
+func (h *PinHandler) ExportPins(ctx context.Context, req *userpb.ExportPinsRequest) (*userpb.ExportPinsReply, error) {
+	bundle, err := h.service.Export(req.Passphrase, req.ConnectionIds...)
+	if err != nil {
+		return nil, err
+	}
+	return &userpb.ExportPinsReply{Bundle: bundle}, nil
+}
+
+func (h *PinHandler) ImportPins(ctx context.Context, req *userpb.ImportPinsRequest) (*userpb.ImportPinsReply, error) {
+	results, err := h.service.Import(req.Bundle, req.Passphrase, pb.ConflictPolicyFromPB(req.OnConflict))
+	if err != nil {
+		return nil, err
+	}
+	rep := &userpb.ImportPinsReply{Pins: make([]*userpb.ImportedPin, len(results))}
+	for i, r := range results {
+		rep.Pins[i] = pb.ImportResultToPB(r)
+	}
+	return rep, nil
+}
@@ -0,0 +1,103 @@
+package repo
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/goccy/go-yaml"
+
+	client "github.com/charadev96/gonec/internal/client/domain"
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+)
+
+const bundleVersion = 1
+
+// bundleAD binds the payload to the bundle format, so that it cannot be
+// passed off as a sealed private key or the other way around.
+var bundleAD = []byte("gonec pin bundle v1")
+
+type bundle struct {
+	Version    int         `yaml:"version"`
+	Encryption *encryption `yaml:"encryption"`
+	Payload    base64Bytes `yaml:"payload"`
+}
+
+// YAMLPinBundleCodec writes bundles as YAML documents whose payload is a
+// sealed list of pins in the plaintext format of the pins file.
+type YAMLPinBundleCodec struct{}
+
+func NewYAMLPinBundleCodec() *YAMLPinBundleCodec {
+	return &YAMLPinBundleCodec{}
+}
+
+func (c *YAMLPinBundleCodec) Seal(pins []client.ConnPin, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, shared.NewError(shared.ErrInvalid, "empty passphrase")
+	}
+
+	data := schema{Conns: make(map[string]*connPin, len(pins))}
+	for _, pin := range pins {
+		if len(pin.User.PrivateKey) == 0 {
+			return nil, client.ErrLocked
+		}
+		data.Conns[pin.ID] = connPinToDB(pin)
+	}
+	raw, err := yaml.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("marshal pins: %w", err)
+	}
+
+	enc, key, err := newEncryption(passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("init encryption: %w", err)
+	}
+	enc.Check = nil
+	payload, err := seal(key, raw, bundleAD)
+	if err != nil {
+		return nil, fmt.Errorf("seal pins: %w", err)
+	}
+
+	return yaml.Marshal(bundle{
+		Version:    bundleVersion,
+		Encryption: enc,
+		Payload:    payload,
+	})
+}
+
+func (c *YAMLPinBundleCodec) Open(raw []byte, passphrase string) ([]client.ConnPin, error) {
+	var b bundle
+	if err := yaml.Unmarshal(raw, &b); err != nil {
+		return nil, shared.NewError(shared.ErrInvalid, "malformed bundle")
+	}
+	if b.Version != bundleVersion {
+		return nil, shared.NewError(shared.ErrInvalid, fmt.Sprintf("unsupported bundle version %d", b.Version))
+	}
+	if b.Encryption == nil {
+		return nil, shared.NewError(shared.ErrInvalid, "malformed bundle")
+	}
+
+	key, err := b.Encryption.deriveKey(passphrase)
+	if err != nil {
+		return nil, shared.NewError(shared.ErrInvalid, err.Error())
+	}
+	plain, err := open(key, b.Payload, bundleAD)
+	if err != nil {
+		return nil, shared.NewError(shared.ErrUnauthenticated, "incorrect passphrase")
+	}
+
+	var data schema
+	if err := yaml.Unmarshal(plain, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal pins: %w", err)
+	}
+	pins := make([]client.ConnPin, 0, len(data.Conns))
+	for _, id := range slices.Sorted(maps.Keys(data.Conns)) {
+		pin := connPinFromDB(data.Conns[id], id)
+		if len(pin.User.PrivateKey) != ed25519.PrivateKeySize {
+			return nil, shared.NewError(shared.ErrInvalid, fmt.Sprintf("pin %q has no private key", id))
+		}
+		pins = append(pins, pin)
+	}
+	return pins, nil
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package repo
+
+// lockFile is a no-op where advisory locks are not available; the
+// in-process mutex still applies.
+func lockFile(path string, exclusive bool) (func(), error) {
+	return func() {}, nil
+}
+
+func syncDir(dir string) error {
+	return nil
+}
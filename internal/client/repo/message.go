@@ -0,0 +1,476 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	client "github.com/charadev96/gonec/internal/client/domain"
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+	"github.com/charadev96/gonec/internal/shared/infra"
+)
+
+// The full-text index is an external content FTS5 table over messages,
+// kept in sync by triggers.
+var messageSearchSchema = []string{
+	`CREATE VIRTUAL TABLE IF NOT EXISTS messages_fts USING fts5(
+		content, content='messages', content_rowid='id'
+	)`,
+	`CREATE TRIGGER IF NOT EXISTS messages_ai AFTER INSERT ON messages BEGIN
+		INSERT INTO messages_fts(rowid, content) VALUES (new.id, new.content);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS messages_ad AFTER DELETE ON messages BEGIN
+		INSERT INTO messages_fts(messages_fts, rowid, content) VALUES ('delete', old.id, old.content);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS messages_au AFTER UPDATE ON messages BEGIN
+		INSERT INTO messages_fts(messages_fts, rowid, content) VALUES ('delete', old.id, old.content);
+		INSERT INTO messages_fts(rowid, content) VALUES (new.id, new.content);
+	END`,
+}
+
+// messageKeyBackfill adds the messages stored before message_keys existed,
+// keeping the first of any already stored twice.
+const messageKeyBackfill = `INSERT INTO message_keys (conn_id, server_id, message_id)
+	SELECT m.conn_id, mm.server_id, mm.message_id
+	FROM message_meta AS mm JOIN messages AS m ON m.id = mm.message_id
+	WHERE true ORDER BY mm.message_id
+	ON CONFLICT (conn_id, server_id) DO NOTHING`
+
+const (
+	defaultMessageLimit = 50
+	maxMessageLimit     = 200
+)
+
+// errDuplicate rolls back saving a message the history already has.
+var errDuplicate = errors.New("duplicate message")
+
+type BunMessageRepository struct {
+	db *bun.DB
+}
+
+func NewBunMessageRepository(ctx context.Context, db *bun.DB) (*BunMessageRepository, error) {
+	r := &BunMessageRepository{
+		db: db,
+	}
+	tx := infra.ExtractTx(ctx, r.db)
+	for _, model := range []any{(*message)(nil), (*messageMeta)(nil), (*messageKey)(nil), (*messageReaction)(nil), (*messageReply)(nil)} {
+		_, err := tx.NewCreateTable().
+			Model(model).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return r, err
+		}
+	}
+	_, err := tx.NewCreateIndex().
+		Model((*message)(nil)).
+		Index("messages_conversation_idx").
+		Column("conn_id", "peer", "id").
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return r, err
+	}
+	_, err = tx.NewCreateIndex().
+		Model((*messageMeta)(nil)).
+		Index("message_meta_server_id_idx").
+		Column("server_id").
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return r, err
+	}
+	if _, err := tx.ExecContext(ctx, messageKeyBackfill); err != nil {
+		return r, err
+	}
+	for _, q := range messageSearchSchema {
+		if _, err := tx.ExecContext(ctx, q); err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
+// Save stores m, unless the history of its connection already has a
+// message with its server ID, whose ID it returns instead.
+func (r *BunMessageRepository) Save(ctx context.Context, m client.StoredMessage) (int64, error) {
+	tx := infra.ExtractTx(ctx, r.db)
+	msg := messageToDB(m)
+	err := tx.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewInsert().
+			Model(msg).
+			Exec(ctx)
+		if err != nil || msg.Meta == nil {
+			return err
+		}
+		res, err := tx.NewInsert().
+			Model(&messageKey{ConnID: msg.ConnID, ServerID: msg.Meta.ServerID, MessageID: msg.ID}).
+			On("CONFLICT (conn_id, server_id) DO NOTHING").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return errDuplicate
+		}
+		msg.Meta.MessageID = msg.ID
+		_, err = tx.NewInsert().
+			Model(msg.Meta).
+			Exec(ctx)
+		if err != nil || msg.Reply == nil {
+			return err
+		}
+		msg.Reply.MessageID = msg.ID
+		_, err = tx.NewInsert().
+			Model(msg.Reply).
+			Exec(ctx)
+		return err
+	})
+	if errors.Is(err, errDuplicate) {
+		var id int64
+		err = tx.NewSelect().
+			Model((*messageKey)(nil)).
+			Column("message_id").
+			Where("conn_id = ?", msg.ConnID).
+			Where("server_id = ?", msg.Meta.ServerID).
+			Scan(ctx, &id)
+		return id, err
+	}
+	if err != nil {
+		return 0, err
+	}
+	return msg.ID, nil
+}
+
+func (r *BunMessageRepository) Edit(ctx context.Context, connID string, e shared.MessageEdit) error {
+	return r.revise(ctx, connID, e.ID, func(q *bun.UpdateQuery) {
+		q.Set("edited_at = ?", e.EditedAt)
+	}, e.Content)
+}
+
+// Delete keeps the message as a tombstone, with its content cleared.
+func (r *BunMessageRepository) Delete(ctx context.Context, connID string, d shared.MessageDelete) error {
+	return r.revise(ctx, connID, d.ID, func(q *bun.UpdateQuery) {
+		q.Set("deleted_at = ?", d.DeletedAt)
+	}, "")
+}
+
+// revise sets the content of the message with serverID in the history of
+// connID, and updates its metadata with set.
+func (r *BunMessageRepository) revise(ctx context.Context, connID string, serverID uuid.UUID, set func(*bun.UpdateQuery), content string) error {
+	tx := infra.ExtractTx(ctx, r.db)
+	return tx.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		id, err := localMessageID(ctx, tx, connID, serverID)
+		if err != nil {
+			return err
+		}
+		_, err = tx.NewUpdate().
+			Model((*message)(nil)).
+			Set("content = ?", content).
+			Where("id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		q := tx.NewUpdate().
+			Model((*messageMeta)(nil)).
+			Where("message_id = ?", id)
+		set(q)
+		_, err = q.Exec(ctx)
+		return err
+	})
+}
+
+func (r *BunMessageRepository) List(ctx context.Context, q client.MessageListQuery) (client.MessageList, error) {
+	tx := infra.ExtractTx(ctx, r.db)
+	if q.Limit < 1 {
+		q.Limit = defaultMessageLimit
+	}
+	q.Limit = min(q.Limit, maxMessageLimit)
+	var msgs []message
+	query := tx.NewSelect().
+		Model(&msgs).
+		Relation("Meta").
+		Relation("Reply").
+		Where("m.conn_id = ?", q.ConnID).
+		Where("m.peer = ?", q.Peer).
+		Limit(q.Limit + 1).
+		Order("m.id DESC")
+	if q.Cursor != 0 {
+		query = query.Where("m.id < ?", q.Cursor)
+	}
+	if err := query.Scan(ctx); err != nil {
+		return client.MessageList{}, err
+	}
+	list := messageListFromDB(msgs, q.Limit)
+	if err := reactionCounts(ctx, tx, list); err != nil {
+		return client.MessageList{}, err
+	}
+	return list, nil
+}
+
+func (r *BunMessageRepository) Search(ctx context.Context, q client.MessageSearchQuery) (client.MessageList, error) {
+	tx := infra.ExtractTx(ctx, r.db)
+	if q.Limit < 1 {
+		q.Limit = defaultMessageLimit
+	}
+	q.Limit = min(q.Limit, maxMessageLimit)
+	match := searchMatch(q.Text)
+	if match == "" {
+		return client.MessageList{}, shared.NewError(shared.ErrInvalid, "empty search text")
+	}
+	var msgs []message
+	query := tx.NewSelect().
+		Model(&msgs).
+		Relation("Meta").
+		Relation("Reply").
+		Join("JOIN messages_fts ON messages_fts.rowid = m.id").
+		Where("messages_fts MATCH ?", match).
+		Where("m.conn_id = ?", q.ConnID).
+		Limit(q.Limit + 1).
+		Order("m.id DESC")
+	if q.Cursor != 0 {
+		query = query.Where("m.id < ?", q.Cursor)
+	}
+	if err := query.Scan(ctx); err != nil {
+		return client.MessageList{}, err
+	}
+	list := messageListFromDB(msgs, q.Limit)
+	if err := reactionCounts(ctx, tx, list); err != nil {
+		return client.MessageList{}, err
+	}
+	return list, nil
+}
+
+func (r *BunMessageRepository) React(ctx context.Context, connID string, rc shared.Reaction) error {
+	tx := infra.ExtractTx(ctx, r.db)
+	return tx.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		id, err := localMessageID(ctx, tx, connID, rc.MessageID)
+		if err != nil {
+			return err
+		}
+		if rc.Removed {
+			_, err = tx.NewDelete().
+				Model((*messageReaction)(nil)).
+				Where("message_id = ?", id).
+				Where("user_id = ?", rc.UserID).
+				Where("emoji = ?", rc.Emoji).
+				Exec(ctx)
+			return err
+		}
+		_, err = tx.NewInsert().
+			Model(&messageReaction{
+				MessageID: id,
+				UserID:    rc.UserID,
+				Emoji:     rc.Emoji,
+			}).
+			Ignore().
+			Exec(ctx)
+		return err
+	})
+}
+
+// localMessageID returns the ID in the history of connID of the message
+// the server assigned serverID, unless it was deleted.
+func localMessageID(ctx context.Context, tx bun.IDB, connID string, serverID uuid.UUID) (int64, error) {
+	var id int64
+	err := tx.NewSelect().
+		Model((*messageMeta)(nil)).
+		Column("mm.message_id").
+		Join("JOIN messages AS m ON m.id = mm.message_id").
+		Where("mm.server_id = ?", serverID).
+		Where("m.conn_id = ?", connID).
+		Where("mm.deleted_at IS NULL").
+		Scan(ctx, &id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = shared.ErrNotExist
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+// reactionCounts adds the reaction counts to the messages of list, in the
+// order the reactions were first made.
+func reactionCounts(ctx context.Context, tx bun.IDB, list client.MessageList) error {
+	if len(list.Messages) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(list.Messages))
+	index := make(map[int64]int, len(list.Messages))
+	for i, m := range list.Messages {
+		ids[i] = m.ID
+		index[m.ID] = i
+	}
+	var counts []struct {
+		MessageID int64
+		Emoji     string
+		Count     int
+	}
+	err := tx.NewSelect().
+		Model((*messageReaction)(nil)).
+		Column("message_id", "emoji").
+		ColumnExpr("COUNT(*) AS count").
+		Where("message_id IN (?)", bun.In(ids)).
+		Group("message_id", "emoji").
+		OrderExpr("MIN(rowid)").
+		Scan(ctx, &counts)
+	if err != nil {
+		return err
+	}
+	for _, c := range counts {
+		m := &list.Messages[index[c.MessageID]]
+		m.Reactions = append(m.Reactions, shared.ReactionCount{Emoji: c.Emoji, Count: c.Count})
+	}
+	return nil
+}
+
+func (r *BunMessageRepository) RenameConn(ctx context.Context, from, to string) error {
+	tx := infra.ExtractTx(ctx, r.db)
+	return tx.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		for _, model := range []any{(*message)(nil), (*messageKey)(nil)} {
+			_, err := tx.NewUpdate().
+				Model(model).
+				Set("conn_id = ?", to).
+				Where("conn_id = ?", from).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// searchMatch turns free text into an FTS5 query matching every word as a
+// literal string, so that user input cannot use the query syntax.
+func searchMatch(text string) string {
+	words := strings.Fields(text)
+	for i, w := range words {
+		words[i] = `"` + strings.ReplaceAll(w, `"`, `""`) + `"`
+	}
+	return strings.Join(words, " ")
+}
+
+type message struct {
+	bun.BaseModel `bun:"table:messages,alias:m"`
+
+	ID        int64            `bun:",pk,autoincrement"`
+	ConnID    string           `bun:",notnull"`
+	Peer      uuid.UUID        `bun:",notnull"`
+	Direction client.Direction `bun:",notnull"`
+	Content   string           `bun:",notnull"`
+	CreatedAt time.Time        `bun:",notnull"`
+
+	Meta  *messageMeta  `bun:"rel:has-one,join:id=message_id"`
+	Reply *messageReply `bun:"rel:has-one,join:id=message_id"`
+}
+
+// messageReply places a message in a thread, for the replies among the
+// messages with a server ID.
+type messageReply struct {
+	bun.BaseModel `bun:"table:message_replies"`
+
+	MessageID int64     `bun:",pk"`
+	ReplyTo   uuid.UUID `bun:",notnull"`
+	ThreadID  uuid.UUID `bun:",notnull"`
+}
+
+type messageReaction struct {
+	bun.BaseModel `bun:"table:message_reactions"`
+
+	MessageID int64     `bun:",pk"`
+	UserID    uuid.UUID `bun:",pk"`
+	Emoji     string    `bun:",pk"`
+}
+
+// messageKey makes the server ID of a message unique in the history of a
+// connection, so that a message delivered twice is kept once.
+type messageKey struct {
+	bun.BaseModel `bun:"table:message_keys"`
+
+	ConnID    string    `bun:",pk"`
+	ServerID  uuid.UUID `bun:",pk"`
+	MessageID int64     `bun:",notnull"`
+}
+
+// messageMeta holds what the server tells about a message beyond its
+// content, for the messages it assigned an ID.
+type messageMeta struct {
+	bun.BaseModel `bun:"table:message_meta,alias:mm"`
+
+	MessageID int64     `bun:",pk"`
+	ServerID  uuid.UUID `bun:",notnull"`
+	EditedAt  time.Time `bun:",nullzero"`
+	DeletedAt time.Time `bun:",nullzero"`
+}
+
+func messageListFromDB(msgs []message, limit int) client.MessageList {
+	var next int64
+	if len(msgs) > limit {
+		msgs = msgs[:limit]
+		next = msgs[len(msgs)-1].ID
+	}
+	list := client.MessageList{
+		Messages: make([]client.StoredMessage, len(msgs)),
+		Cursor:   next,
+	}
+	for i, m := range msgs {
+		list.Messages[i] = messageFromDB(m)
+	}
+	return list
+}
+
+func messageFromDB(m message) client.StoredMessage {
+	msg := client.StoredMessage{
+		ID:        m.ID,
+		ConnID:    m.ConnID,
+		Peer:      m.Peer,
+		Direction: m.Direction,
+		Content:   m.Content,
+		CreatedAt: m.CreatedAt,
+	}
+	if m.Meta != nil {
+		msg.MessageID = m.Meta.ServerID
+		msg.EditedAt = m.Meta.EditedAt
+		msg.DeletedAt = m.Meta.DeletedAt
+	}
+	if m.Reply != nil {
+		msg.ReplyTo = m.Reply.ReplyTo
+		msg.ThreadID = m.Reply.ThreadID
+	}
+	return msg
+}
+
+func messageToDB(m client.StoredMessage) *message {
+	msg := &message{
+		ID:        m.ID,
+		ConnID:    m.ConnID,
+		Peer:      m.Peer,
+		Direction: m.Direction,
+		Content:   m.Content,
+		CreatedAt: m.CreatedAt,
+	}
+	if m.MessageID != uuid.Nil {
+		msg.Meta = &messageMeta{
+			ServerID:  m.MessageID,
+			EditedAt:  m.EditedAt,
+			DeletedAt: m.DeletedAt,
+		}
+		if m.ReplyTo != uuid.Nil {
+			msg.Reply = &messageReply{
+				ReplyTo:  m.ReplyTo,
+				ThreadID: m.ThreadID,
+			}
+		}
+	}
+	return msg
+}
@@ -3,10 +3,14 @@ package repo
 import (
 	"crypto/ed25519"
 	"encoding/base64"
+	"errors"
 	"fmt"
-	"io"
+	"io/fs"
+	"maps"
 	"os"
-	"time"
+	"path/filepath"
+	"slices"
+	"sync"
 
 	"github.com/goccy/go-yaml"
 	"github.com/google/uuid"
@@ -16,64 +20,210 @@ import (
 )
 
 const (
-	permRepository = 0644
+	permRepository = 0600
 )
 
 type YAMLConnPinRepository struct {
 	file string
 
-	data       schema
-	modifiedAt time.Time
+	// mu serializes access within the process, the advisory lock on the
+	// lock file next to the repository across processes.
+	mu   sync.Mutex
+	data schema
+
+	// key is derived from the passphrase on Unlock and opens the sealed
+	// private keys.
+	key []byte
 }
 
 func NewYAMLConnPinRepository(f string) *YAMLConnPinRepository {
 	r := &YAMLConnPinRepository{
 		file: f,
-		data: schema{make(map[string]*connPin)},
+		data: schema{Conns: make(map[string]*connPin)},
 	}
 	return r
 }
 
 func (r *YAMLConnPinRepository) Get(id string) (client.ConnPin, error) {
-	modified, err := r.fileModified()
-	if err != nil {
-		return client.ConnPin{}, fmt.Errorf("compare timestamp: %w", err)
-	}
-	if modified {
-		if err := r.load(); err != nil {
-			return client.ConnPin{}, fmt.Errorf("load repository: %w", err)
+	var pin client.ConnPin
+	err := r.view(func() error {
+		p, ok := r.data.Conns[id]
+		if !ok {
+			return fmt.Errorf("%q: %w", id, shared.ErrNotExist)
 		}
-	}
-	p, ok := r.data.Conns[id]
-	if !ok {
-		return client.ConnPin{}, fmt.Errorf("%q: %w", id, shared.ErrNotExist)
-	}
-	return connPinFromDB(p, id), nil
+		var err error
+		pin, err = r.openConnPin(p, id)
+		return err
+	})
+	return pin, err
+}
+
+func (r *YAMLConnPinRepository) List() ([]client.ConnPin, error) {
+	var pins []client.ConnPin
+	err := r.view(func() error {
+		pins = make([]client.ConnPin, 0, len(r.data.Conns))
+		for _, id := range slices.Sorted(maps.Keys(r.data.Conns)) {
+			pin, err := r.openConnPin(r.data.Conns[id], id)
+			if err != nil {
+				return err
+			}
+			pins = append(pins, pin)
+		}
+		return nil
+	})
+	return pins, err
 }
 
 func (r *YAMLConnPinRepository) Set(id string, pin client.ConnPin) error {
-	modified, err := r.fileModified()
-	if err != nil {
-		return fmt.Errorf("compare timestamp: %w", err)
-	}
-	if modified {
-		if err := r.load(); err != nil {
-			return fmt.Errorf("load repository: %w", err)
+	return r.update(func() (bool, error) {
+		p, err := r.sealConnPin(pin, r.data.Conns[id])
+		if err != nil {
+			return false, err
+		}
+		r.data.Conns[id] = p
+		return true, nil
+	})
+}
+
+func (r *YAMLConnPinRepository) Merge(fn func(taken map[string]bool) ([]client.ConnPin, error)) error {
+	return r.update(func() (bool, error) {
+		taken := make(map[string]bool, len(r.data.Conns))
+		for id := range r.data.Conns {
+			taken[id] = true
+		}
+		pins, err := fn(taken)
+		if err != nil {
+			return false, err
+		}
+		sealed := make(map[string]*connPin, len(pins))
+		for _, pin := range pins {
+			p, err := r.sealConnPin(pin, r.data.Conns[pin.ID])
+			if err != nil {
+				return false, err
+			}
+			sealed[pin.ID] = p
+		}
+		maps.Copy(r.data.Conns, sealed)
+		return len(sealed) > 0, nil
+	})
+}
+
+func (r *YAMLConnPinRepository) Delete(id string) error {
+	return r.update(func() (bool, error) {
+		_, ok := r.data.Conns[id]
+		if !ok {
+			return false, fmt.Errorf("%q: %w", id, shared.ErrNotExist)
+		}
+		delete(r.data.Conns, id)
+		return true, nil
+	})
+}
+
+func (r *YAMLConnPinRepository) Rename(from, to string) error {
+	return r.update(func() (bool, error) {
+		p, ok := r.data.Conns[from]
+		if !ok {
+			return false, fmt.Errorf("%q: %w", from, shared.ErrNotExist)
+		}
+		if _, ok := r.data.Conns[to]; ok {
+			return false, fmt.Errorf("%q: %w", to, shared.ErrExist)
 		}
+		r.data.Conns[to] = p
+		delete(r.data.Conns, from)
+		return true, nil
+	})
+}
+
+// Unlock derives the key sealing the private keys from passphrase. The
+// first unlock of a plaintext file sets the passphrase and seals every key
+// in it.
+func (r *YAMLConnPinRepository) Unlock(passphrase string) error {
+	if passphrase == "" {
+		return shared.NewError(shared.ErrInvalid, "empty passphrase")
 	}
-	r.data.Conns[id] = connPinToDB(pin)
-	if err := r.save(); err != nil {
-		return fmt.Errorf("save repository: %w", err)
+
+	var key []byte
+	err := r.update(func() (bool, error) {
+		created := r.data.Encryption == nil
+		if created {
+			enc, k, err := newEncryption(passphrase)
+			if err != nil {
+				return false, fmt.Errorf("init encryption: %w", err)
+			}
+			r.data.Encryption = enc
+			key = k
+		} else {
+			k, err := r.data.Encryption.deriveKey(passphrase)
+			if err != nil {
+				return false, fmt.Errorf("derive key: %w", err)
+			}
+			if _, err := open(k, r.data.Encryption.Check, nil); err != nil {
+				return false, shared.NewError(shared.ErrUnauthenticated, "incorrect passphrase")
+			}
+			key = k
+		}
+
+		migrated := false
+		for _, p := range r.data.Conns {
+			if len(p.User.PrivateKey) == 0 {
+				continue
+			}
+			sealed, err := seal(key, p.User.PrivateKey, p.User.ID[:])
+			if err != nil {
+				return false, fmt.Errorf("seal private key: %w", err)
+			}
+			p.User.SealedPrivateKey = sealed
+			p.User.PrivateKey = nil
+			migrated = true
+		}
+		return created || migrated, nil
+	})
+	if err != nil {
+		return err
 	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.key = key
 	return nil
 }
 
-func (r *YAMLConnPinRepository) Delete(id string) error {
-	_, ok := r.data.Conns[id]
-	if !ok {
-		return fmt.Errorf("%q: %w", id, shared.ErrNotExist)
+// view runs fn on the current contents of the file under a shared lock.
+func (r *YAMLConnPinRepository) view(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	unlock, err := lockFile(r.file+".lock", false)
+	if err != nil {
+		return fmt.Errorf("lock repository: %w", err)
+	}
+	defer unlock()
+
+	if err := r.load(); err != nil {
+		return fmt.Errorf("load repository: %w", err)
+	}
+	return fn()
+}
+
+// update runs fn on the current contents of the file under an exclusive
+// lock, and writes them back if fn reports a change.
+func (r *YAMLConnPinRepository) update(fn func() (bool, error)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	unlock, err := lockFile(r.file+".lock", true)
+	if err != nil {
+		return fmt.Errorf("lock repository: %w", err)
+	}
+	defer unlock()
+
+	if err := r.load(); err != nil {
+		return fmt.Errorf("load repository: %w", err)
+	}
+	changed, err := fn()
+	if err != nil || !changed {
+		return err
 	}
-	delete(r.data.Conns, id)
 	if err := r.save(); err != nil {
 		return fmt.Errorf("save repository: %w", err)
 	}
@@ -147,9 +297,12 @@ type connPin struct {
 		ID         uuid.UUID  `yaml:"id"`
 		Name       string     `yaml:"name,omitempty"`
 		PrivateKey privateKey `yaml:"private_key,omitempty"`
+
+		SealedPrivateKey base64Bytes `yaml:"sealed_private_key,omitempty"`
 	} `yaml:"user"`
 	Server struct {
-		IPAddress string    `yaml:"ip_address"`
+		IPAddress string    `yaml:"ip_address,omitempty"`
+		Host      string    `yaml:"host,omitempty"`
 		PublicKey publicKey `yaml:"public_key"`
 	} `yaml:"server"`
 }
@@ -159,10 +312,12 @@ func connPinFromDB(p *connPin, id string) client.ConnPin {
 		ID: id,
 		User: client.UserPrivateIdentity{
 			ID:         p.User.ID,
+			Name:       p.User.Name,
 			PrivateKey: ed25519.PrivateKey(p.User.PrivateKey),
 		},
 		Server: shared.ServerIdentity{
 			IPAddress: p.Server.IPAddress,
+			Host:      p.Server.Host,
 			PublicKey: ed25519.PublicKey(p.Server.PublicKey),
 		},
 	}
@@ -171,39 +326,63 @@ func connPinFromDB(p *connPin, id string) client.ConnPin {
 func connPinToDB(pin client.ConnPin) *connPin {
 	p := &connPin{}
 	p.User.ID = pin.User.ID
-	p.User.Name = ""
+	p.User.Name = pin.User.Name
 	p.User.PrivateKey = privateKey(pin.User.PrivateKey)
 	p.Server.IPAddress = pin.Server.IPAddress
+	p.Server.Host = pin.Server.Host
 	p.Server.PublicKey = publicKey(pin.Server.PublicKey)
 	return p
 }
 
-type schema struct {
-	Conns map[string]*connPin `yaml:"connections"`
+// openConnPin opens the sealed private key of p if the repository is
+// unlocked, and leaves it empty otherwise.
+func (r *YAMLConnPinRepository) openConnPin(p *connPin, id string) (client.ConnPin, error) {
+	pin := connPinFromDB(p, id)
+	if len(p.User.SealedPrivateKey) > 0 && r.key != nil {
+		key, err := open(r.key, p.User.SealedPrivateKey, p.User.ID[:])
+		if err != nil {
+			return client.ConnPin{}, fmt.Errorf("open private key of %q: %w", id, err)
+		}
+		pin.User.PrivateKey = key
+	}
+	return pin, nil
 }
 
-func (r *YAMLConnPinRepository) fileModified() (bool, error) {
-	info, err := os.Stat(r.file)
-	if err != nil {
-		return false, err
-	}
-	modTime := info.ModTime()
-	mod := !r.modifiedAt.Equal(modTime)
-	if mod {
-		r.modifiedAt = modTime
+// sealConnPin seals the private key of pin if encryption is set up. A pin
+// without a private key, as returned while locked, keeps the one stored in
+// prev.
+func (r *YAMLConnPinRepository) sealConnPin(pin client.ConnPin, prev *connPin) (*connPin, error) {
+	p := connPinToDB(pin)
+	switch {
+	case len(pin.User.PrivateKey) == 0:
+		if prev != nil {
+			p.User.PrivateKey = prev.User.PrivateKey
+			p.User.SealedPrivateKey = prev.User.SealedPrivateKey
+		}
+	case r.data.Encryption == nil:
+	case r.key == nil:
+		return nil, client.ErrLocked
+	default:
+		sealed, err := seal(r.key, pin.User.PrivateKey, pin.User.ID[:])
+		if err != nil {
+			return nil, fmt.Errorf("seal private key: %w", err)
+		}
+		p.User.PrivateKey = nil
+		p.User.SealedPrivateKey = sealed
 	}
-	return mod, nil
+	return p, nil
 }
 
-func (r *YAMLConnPinRepository) load() error {
-	f, err := os.OpenFile(r.file, os.O_RDONLY, permRepository)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
+type schema struct {
+	Encryption *encryption         `yaml:"encryption,omitempty"`
+	Conns      map[string]*connPin `yaml:"connections"`
+}
 
-	raw, err := io.ReadAll(f)
-	if err != nil {
+// load reads the file, treating a missing one as empty.
+func (r *YAMLConnPinRepository) load() error {
+	r.data = schema{}
+	raw, err := os.ReadFile(r.file)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
 		return fmt.Errorf("read file %s: %w", r.file, err)
 	}
 
@@ -218,22 +397,40 @@ func (r *YAMLConnPinRepository) load() error {
 	return nil
 }
 
+// save replaces the file atomically with a synced temporary file, so that
+// a crash leaves either the old or the new contents.
 func (r *YAMLConnPinRepository) save() error {
 	raw, err := yaml.Marshal(r.data)
 	if err != nil {
 		return fmt.Errorf("marshal yaml: %w", err)
 	}
 
-	f, err := os.OpenFile(r.file, os.O_WRONLY|os.O_TRUNC, permRepository)
+	dir := filepath.Dir(r.file)
+	f, err := os.CreateTemp(dir, filepath.Base(r.file)+".tmp*")
 	if err != nil {
 		return err
 	}
-	defer f.Close()
+	tmp := f.Name()
+	defer os.Remove(tmp)
 
-	_, err = f.Write(raw)
-	if err != nil {
+	if err := f.Chmod(permRepository); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(raw); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, r.file); err != nil {
 		return err
 	}
 
-	return nil
+	return syncDir(dir)
 }
@@ -0,0 +1,152 @@
+package repo_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+
+	client "github.com/charadev96/gonec/internal/client/domain"
+	"github.com/charadev96/gonec/internal/client/repo"
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+)
+
+func newPin(t *testing.T, id string) client.ConnPin {
+	t.Helper()
+	serverKey, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, userKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client.ConnPin{
+		ID:     id,
+		User:   client.UserPrivateIdentity{ID: uuid.New(), PrivateKey: userKey},
+		Server: shared.ServerIdentity{IPAddress: "127.0.0.1:1", PublicKey: serverKey},
+	}
+}
+
+func TestPinSealing(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "pins.yaml")
+	pin := newPin(t, "home")
+
+	r := repo.NewYAMLConnPinRepository(file)
+	if err := r.Set(pin.ID, pin); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Unlock("correct horse"); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(raw, []byte(base64.StdEncoding.EncodeToString(pin.User.PrivateKey))) {
+		t.Fatalf("private key left in the clear after unlock:\n%s", raw)
+	}
+
+	locked := repo.NewYAMLConnPinRepository(file)
+	got, err := locked.Get(pin.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.User.PrivateKey) != 0 {
+		t.Fatal("locked repository returned a private key")
+	}
+	if err := locked.Set("work", newPin(t, "work")); !errors.Is(err, client.ErrLocked) {
+		t.Fatalf("set while locked: got %v, want ErrLocked", err)
+	}
+	if err := locked.Unlock("wrong"); !errors.Is(err, shared.ErrUnauthenticated) {
+		t.Fatalf("unlock with wrong passphrase: got %v, want ErrUnauthenticated", err)
+	}
+
+	if err := locked.Unlock("correct horse"); err != nil {
+		t.Fatal(err)
+	}
+	got, err = locked.Get(pin.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.User.PrivateKey.Equal(pin.User.PrivateKey) {
+		t.Fatal("unlocked private key differs from the stored one")
+	}
+}
+
+func TestPinBundle(t *testing.T) {
+	c := repo.NewYAMLPinBundleCodec()
+	pins := []client.ConnPin{newPin(t, "home"), newPin(t, "work")}
+
+	raw, err := c.Seal(pins, "bundle pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range pins {
+		if bytes.Contains(raw, p.User.PrivateKey) {
+			t.Fatal("bundle holds a private key in the clear")
+		}
+	}
+
+	got, err := c.Open(raw, "bundle pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(pins) {
+		t.Fatalf("opened %d pins, want %d", len(got), len(pins))
+	}
+	for i := range pins {
+		if got[i].ID != pins[i].ID || !got[i].User.PrivateKey.Equal(pins[i].User.PrivateKey) {
+			t.Fatalf("pin %d differs after round trip", i)
+		}
+	}
+
+	if _, err := c.Open(raw, "other pass"); !errors.Is(err, shared.ErrUnauthenticated) {
+		t.Fatalf("open with wrong passphrase: got %v, want ErrUnauthenticated", err)
+	}
+	future := bytes.Replace(raw, []byte("version: 1"), []byte("version: 2"), 1)
+	if _, err := c.Open(future, "bundle pass"); !errors.Is(err, shared.ErrInvalid) {
+		t.Fatalf("open newer version: got %v, want ErrInvalid", err)
+	}
+	locked := pins[0]
+	locked.User.PrivateKey = nil
+	if _, err := c.Seal([]client.ConnPin{locked}, "bundle pass"); !errors.Is(err, client.ErrLocked) {
+		t.Fatalf("seal locked pin: got %v, want ErrLocked", err)
+	}
+}
+
+func TestPinMergeSeesTakenIDs(t *testing.T) {
+	r := repo.NewYAMLConnPinRepository(filepath.Join(t.TempDir(), "pins.yaml"))
+	if err := r.Set("home", newPin(t, "home")); err != nil {
+		t.Fatal(err)
+	}
+
+	err := r.Merge(func(taken map[string]bool) ([]client.ConnPin, error) {
+		if !taken["home"] || taken["work"] {
+			t.Errorf("taken %v, want only home", taken)
+		}
+		return []client.ConnPin{newPin(t, "work")}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pins, err := r.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pins) != 2 {
+		t.Fatalf("listed %d pins after merge, want 2", len(pins))
+	}
+
+	err = r.Merge(func(map[string]bool) ([]client.ConnPin, error) {
+		return nil, shared.ErrExist
+	})
+	if !errors.Is(err, shared.ErrExist) {
+		t.Fatalf("got %v, want the error of the merge", err)
+	}
+}
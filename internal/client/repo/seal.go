@@ -0,0 +1,101 @@
+package repo
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	kdfArgon2id = "argon2id"
+
+	argonTime    = 3
+	argonMemory  = 64 * 1024
+	argonThreads = 4
+	saltSize     = 16
+)
+
+// sealCheck is sealed under the derived key to tell a wrong passphrase
+// apart from a corrupted private key.
+var sealCheck = []byte("gonec pins")
+
+type encryption struct {
+	KDF     string      `yaml:"kdf"`
+	Salt    base64Bytes `yaml:"salt"`
+	Time    uint32      `yaml:"time"`
+	Memory  uint32      `yaml:"memory"`
+	Threads uint8       `yaml:"threads"`
+	Check   base64Bytes `yaml:"check,omitempty"`
+}
+
+func newEncryption(passphrase string) (*encryption, []byte, error) {
+	e := &encryption{
+		KDF:     kdfArgon2id,
+		Salt:    make([]byte, saltSize),
+		Time:    argonTime,
+		Memory:  argonMemory,
+		Threads: argonThreads,
+	}
+	if _, err := rand.Read(e.Salt); err != nil {
+		return nil, nil, err
+	}
+	key, err := e.deriveKey(passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	e.Check, err = seal(key, sealCheck, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return e, key, nil
+}
+
+func (e *encryption) deriveKey(passphrase string) ([]byte, error) {
+	if e.KDF != kdfArgon2id {
+		return nil, fmt.Errorf("unsupported kdf %q", e.KDF)
+	}
+	return argon2.IDKey([]byte(passphrase), e.Salt, e.Time, e.Memory, e.Threads, chacha20poly1305.KeySize), nil
+}
+
+// seal encrypts plaintext with XChaCha20-Poly1305, prefixing the random
+// nonce to the ciphertext.
+func seal(key, plaintext, ad []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, ad), nil
+}
+
+func open(key, sealed, ad []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("sealed data too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, ad)
+}
+
+type base64Bytes []byte
+
+func (b *base64Bytes) UnmarshalYAML(text []byte) error {
+	dst, err := base64.StdEncoding.DecodeString(string(text))
+	if err == nil {
+		*b = dst
+	}
+	return err
+}
+
+func (b base64Bytes) MarshalYAML() ([]byte, error) {
+	return []byte(base64.StdEncoding.EncodeToString(b)), nil
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+
+	gatewaypb "github.com/charadev96/gonec/gen/gateway"
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+	pb "github.com/charadev96/gonec/internal/shared/pb"
+)
+
+const attachmentChunkSize = 64 << 10
+
+type AttachmentService struct {
+	auth *AuthService
+}
+
+func NewAttachmentService(a *AuthService) *AttachmentService {
+	return &AttachmentService{auth: a}
+}
+
+// Upload stores the contents of r on the server of the connection as an
+// attachment of type mimeType, to be referenced by messages sent on it.
+func (s *AttachmentService) Upload(ctx context.Context, connID string, mimeType string, r io.Reader) (shared.Attachment, error) {
+	cl, err := BindClient(s.auth, connID, gatewaypb.NewAttachmentServiceClient)
+	if err != nil {
+		return shared.Attachment{}, err
+	}
+	session, err := s.auth.Session(connID)
+	if err != nil {
+		return shared.Attachment{}, fmt.Errorf("get active session: %w", err)
+	}
+
+	stream, err := cl.Upload(ctx)
+	if err != nil {
+		return shared.Attachment{}, fmt.Errorf("request upload: %w", err)
+	}
+	err = stream.Send(&gatewaypb.UploadRequest{
+		Payload: &gatewaypb.UploadRequest_Open{Open: &gatewaypb.UploadOpen{
+			Auth:     pb.SessionToPB(session),
+			MimeType: mimeType,
+		}},
+	})
+	buf := make([]byte, attachmentChunkSize)
+	for err == nil {
+		var n int
+		n, err = r.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&gatewaypb.UploadRequest{
+				Payload: &gatewaypb.UploadRequest_Chunk{Chunk: buf[:n]},
+			}); err != nil {
+				break
+			}
+		}
+	}
+	// The server ends the stream early on failure, its reply has the cause.
+	if err != nil && !errors.Is(err, io.EOF) {
+		_ = stream.CloseSend()
+		if _, rerr := stream.CloseAndRecv(); rerr != nil {
+			return shared.Attachment{}, fmt.Errorf("request upload: %w", rerr)
+		}
+		return shared.Attachment{}, fmt.Errorf("read attachment: %w", err)
+	}
+
+	reply, err := stream.CloseAndRecv()
+	if err != nil {
+		return shared.Attachment{}, fmt.Errorf("request upload: %w", err)
+	}
+	return pb.AttachmentFromPB(reply.Attachment)
+}
+
+// Download opens the attachment with id on the server of the connection.
+// Reading it fails at the end if the contents do not match its hash, and
+// acknowledges the download to the server otherwise.
+func (s *AttachmentService) Download(ctx context.Context, connID string, id uuid.UUID) (shared.Attachment, io.Reader, error) {
+	cl, err := BindClient(s.auth, connID, gatewaypb.NewAttachmentServiceClient)
+	if err != nil {
+		return shared.Attachment{}, nil, err
+	}
+	session, err := s.auth.Session(connID)
+	if err != nil {
+		return shared.Attachment{}, nil, fmt.Errorf("get active session: %w", err)
+	}
+
+	stream, err := cl.Download(ctx, &gatewaypb.DownloadRequest{
+		Auth:         pb.SessionToPB(session),
+		AttachmentId: id.String(),
+	})
+	if err != nil {
+		return shared.Attachment{}, nil, fmt.Errorf("request download: %w", err)
+	}
+	reply, err := stream.Recv()
+	if err != nil {
+		return shared.Attachment{}, nil, fmt.Errorf("request download: %w", err)
+	}
+	if reply.GetAttachment() == nil {
+		return shared.Attachment{}, nil, errors.New("download did not start with the attachment")
+	}
+	a, err := pb.AttachmentFromPB(reply.GetAttachment())
+	if err != nil {
+		return shared.Attachment{}, nil, fmt.Errorf("parse attachment: %w", err)
+	}
+	ack := func() error {
+		_, err := cl.AckDownload(ctx, &gatewaypb.AckDownloadRequest{
+			Auth:         pb.SessionToPB(session),
+			AttachmentId: id.String(),
+		})
+		return err
+	}
+	return a, &downloadReader{stream: stream, want: a.Hash, hash: sha256.New(), ack: ack}, nil
+}
+
+type downloadReader struct {
+	stream grpc.ServerStreamingClient[gatewaypb.DownloadReply]
+	buf    []byte
+	want   []byte
+	hash   hash.Hash
+	ack    func() error
+}
+
+func (r *downloadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		reply, err := r.stream.Recv()
+		if errors.Is(err, io.EOF) {
+			if !bytes.Equal(r.hash.Sum(nil), r.want) {
+				return 0, errors.New("attachment hash mismatch")
+			}
+			if r.ack != nil {
+				if err := r.ack(); err != nil {
+					return 0, fmt.Errorf("acknowledge download: %w", err)
+				}
+				r.ack = nil
+			}
+			return 0, io.EOF
+		}
+		if err != nil {
+			return 0, fmt.Errorf("request download: %w", err)
+		}
+		r.buf = reply.GetChunk()
+		r.hash.Write(r.buf)
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
@@ -6,9 +6,14 @@ import (
 	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
@@ -17,6 +22,7 @@ import (
 	gatewaypb "github.com/charadev96/gonec/gen/gateway"
 	client "github.com/charadev96/gonec/internal/client/domain"
 	shared "github.com/charadev96/gonec/internal/shared/domain"
+	"github.com/charadev96/gonec/internal/shared/log"
 	pb "github.com/charadev96/gonec/internal/shared/pb"
 )
 
@@ -25,16 +31,30 @@ type AuthServiceStatus int
 const (
 	AuthDisconnected AuthServiceStatus = iota
 	AuthConnected
+	AuthPendingTrust
 	AuthLoggedIn
 )
 
-type AuthService struct {
-	pins client.ConnPinRepository
+const reconnectTimeout = 10 * time.Second
+
+type connection struct {
+	mu sync.Mutex
 
 	pin     client.ConnPin
 	conn    *grpc.ClientConn
 	session *shared.Session
 	status  AuthServiceStatus
+	relogin bool
+}
+
+type AuthService struct {
+	pins client.ConnPinRepository
+
+	mu    sync.Mutex
+	conns map[string]*connection
+
+	trustMu sync.Mutex
+	pending map[string]client.PendingTrust
 
 	rand io.Reader
 }
@@ -49,8 +69,10 @@ func AuthWithRand(r io.Reader) AuthServiceOption {
 
 func NewAuthService(p client.ConnPinRepository, opts ...AuthServiceOption) *AuthService {
 	s := &AuthService{
-		pins: p,
-		rand: rand.Reader,
+		pins:    p,
+		conns:   make(map[string]*connection),
+		pending: make(map[string]client.PendingTrust),
+		rand:    rand.Reader,
 	}
 	for _, opt := range opts {
 		opt(s)
@@ -59,7 +81,9 @@ func NewAuthService(p client.ConnPinRepository, opts ...AuthServiceOption) *Auth
 }
 
 func (s *AuthService) Register(ctx context.Context, id string, t shared.InviteTicket) error {
-	if s.status == AuthLoggedIn {
+	c, _ := s.acquire(id)
+	defer c.mu.Unlock()
+	if c.status == AuthLoggedIn {
 		return client.ErrLoggedIn
 	}
 
@@ -81,23 +105,24 @@ func (s *AuthService) Register(ctx context.Context, id string, t shared.InviteTi
 		return fmt.Errorf("set pin: %w", err)
 	}
 
-	err = s.connect(ctx, id)
+	err = s.connect(c, id)
 	if err != nil {
 		return fmt.Errorf("connect to server: %w", err)
 	}
-	defer s.disconnect()
-
-	cl, err := BindClient(s, gatewaypb.NewAuthServiceClient)
-	if err != nil {
-		return err
-	}
+	defer s.disconnect(c)
 
+	cl := gatewaypb.NewAuthServiceClient(c.conn)
 	_, err = cl.Register(ctx, &gatewaypb.RegisterRequest{
 		UserId:    t.Credential.UserID.String(),
 		Token:     t.Credential.Token,
 		PublicKey: pub,
 	})
 	if err != nil {
+		// The ticket is the only source of the server key at this point, so
+		// a mismatch is not something the user can vouch for.
+		if _, ok := s.takePendingTrust(id); ok {
+			return fmt.Errorf("server key does not match invite ticket: %w", client.ErrUntrusted)
+		}
 		return fmt.Errorf("request register: %w", err)
 	}
 
@@ -105,117 +130,359 @@ func (s *AuthService) Register(ctx context.Context, id string, t shared.InviteTi
 }
 
 func (s *AuthService) Login(ctx context.Context, id string) error {
-	if s.status == AuthLoggedIn {
+	c, _ := s.acquire(id)
+	defer c.mu.Unlock()
+	return s.login(ctx, c, id)
+}
+
+// Relogin replaces the session stale of connection id with a new one over
+// a fresh transport. It is a no-op if the session was already replaced, and
+// fails with ErrNoLoggedIn once the user has logged out.
+func (s *AuthService) Relogin(ctx context.Context, id string, stale shared.Session) error {
+	c, err := s.lookup(id)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.relogin {
+		return client.ErrNoLoggedIn
+	}
+	if c.status == AuthLoggedIn {
+		if c.session.ID != stale.ID {
+			return nil
+		}
+		s.disconnect(c)
+	}
+	return s.login(ctx, c, id)
+}
+
+func (s *AuthService) login(ctx context.Context, c *connection, id string) error {
+	var callOpts []grpc.CallOption
+	switch c.status {
+	case AuthLoggedIn:
 		return client.ErrLoggedIn
+	case AuthPendingTrust:
+		return s.pendingTrustError(id)
+	case AuthConnected:
+		// Left connected by an accepted trust decision, the transport may
+		// still be backing off from the rejected handshake.
+		ctxReady, cancel := context.WithTimeout(ctx, reconnectTimeout)
+		defer cancel()
+		ctx = ctxReady
+		callOpts = append(callOpts, grpc.WaitForReady(true))
+	case AuthDisconnected:
+		if err := s.connect(c, id); err != nil {
+			return fmt.Errorf("connect to server: %w", err)
+		}
 	}
 
 	fail := true
-	err := s.connect(ctx, id)
 	defer func() {
-		if fail {
-			s.disconnect()
+		if !fail {
+			return
+		}
+		if _, ok := s.PendingTrust(id); ok {
+			c.status = AuthPendingTrust
+			return
 		}
+		s.disconnect(c)
 	}()
 
-	if err != nil {
-		return fmt.Errorf("connect to server: %w", err)
+	if len(c.pin.User.PrivateKey) == 0 {
+		return client.ErrLocked
 	}
 
-	cl, err := BindClient(s, gatewaypb.NewAuthServiceClient)
-	if err != nil {
-		return err
+	cl := gatewaypb.NewAuthServiceClient(c.conn)
+	initiate := &gatewaypb.InitiateLoginRequest{
+		UserId: c.pin.User.ID.String(),
 	}
-
-	pin, err := s.Pin()
-	if err != nil {
-		return fmt.Errorf("get active pin: %w", err)
+	repInitiate, err := cl.InitiateLogin(ctx, initiate, callOpts...)
+	if p, ok := s.PendingTrust(id); err != nil && ok {
+		if err := s.applyKeyRollover(ctx, c, p); err != nil {
+			return s.pendingTrustError(id)
+		}
+		ctxReady, cancel := context.WithTimeout(ctx, reconnectTimeout)
+		defer cancel()
+		repInitiate, err = cl.InitiateLogin(ctxReady, initiate, grpc.WaitForReady(true))
 	}
-
-	repInitiate, err := cl.InitiateLogin(ctx, &gatewaypb.InitiateLoginRequest{
-		UserId: pin.User.ID.String(),
-	})
 	if err != nil {
 		return fmt.Errorf("request login request: %w", err)
 	}
 
-	sig := ed25519.Sign(pin.User.PrivateKey, repInitiate.Nonce)
+	sig := ed25519.Sign(c.pin.User.PrivateKey, repInitiate.Nonce)
 	repComplete, err := cl.CompleteLogin(ctx, &gatewaypb.CompleteLoginRequest{
-		UserId:    pin.User.ID.String(),
+		UserId:    c.pin.User.ID.String(),
 		Signature: sig,
 	})
 	if err != nil {
 		return fmt.Errorf("request complete login: %w", err)
 	}
 
-	fail = false
-	s.session = &shared.Session{}
-	*s.session, err = pb.SessionFromPB(repComplete.Auth)
+	session, err := pb.SessionFromPB(repComplete.Auth)
 	if err != nil {
 		return fmt.Errorf("parse session: %w", err)
 	}
-	s.status = AuthLoggedIn
+	fail = false
+	c.session = &session
+	c.status = AuthLoggedIn
+	c.relogin = true
 
 	return nil
 }
 
-func (s *AuthService) Logout(ctx context.Context) error {
-	cl, err := BindClient(s, gatewaypb.NewAuthServiceClient)
+func (s *AuthService) Logout(ctx context.Context, id string) error {
+	c, err := s.lookup(id)
 	if err != nil {
 		return err
 	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.relogin = false
+	if c.status != AuthLoggedIn {
+		return client.ErrNoLoggedIn
+	}
 
-	session, err := s.Session()
+	defer s.disconnect(c)
+	_, err = gatewaypb.NewAuthServiceClient(c.conn).Logout(ctx, &gatewaypb.LogoutRequest{
+		Auth: pb.SessionToPB(*c.session),
+	})
 	if err != nil {
-		return fmt.Errorf("get active session: %w", err)
+		return fmt.Errorf("request logout: %w", err)
 	}
 
-	defer s.disconnect()
-	_, err = cl.Logout(ctx, &gatewaypb.LogoutRequest{
-		Auth: pb.SessionToPB(session),
+	return nil
+}
+
+func (s *AuthService) Unlock(passphrase string) error {
+	return s.pins.Unlock(passphrase)
+}
+
+// PendingTrust returns the key held for a trust decision on connection id.
+func (s *AuthService) PendingTrust(id string) (client.PendingTrust, bool) {
+	s.trustMu.Lock()
+	defer s.trustMu.Unlock()
+	p, ok := s.pending[id]
+	return p, ok
+}
+
+func (s *AuthService) PendingTrusts() []client.PendingTrust {
+	s.trustMu.Lock()
+	defer s.trustMu.Unlock()
+	ps := make([]client.PendingTrust, 0, len(s.pending))
+	for _, p := range s.pending {
+		ps = append(ps, p)
+	}
+	slices.SortFunc(ps, func(a, b client.PendingTrust) int {
+		return strings.Compare(a.ConnID, b.ConnID)
 	})
+	return ps
+}
+
+func (s *AuthService) AcceptTrust(id, fingerprint string) error {
+	c, err := s.lookup(id)
 	if err != nil {
-		return fmt.Errorf("request logout: %w", err)
+		return fmt.Errorf("pending trust %q: %w", id, shared.ErrNotExist)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s.trustMu.Lock()
+	defer s.trustMu.Unlock()
+	p, ok := s.pending[id]
+	if !ok {
+		return fmt.Errorf("pending trust %q: %w", id, shared.ErrNotExist)
+	}
+	if p.Fingerprint != fingerprint {
+		return shared.NewError(shared.ErrFailedPrecondition, "fingerprint does not match pending key")
 	}
 
-	s.session = nil
+	pin, err := s.pins.Get(id)
+	if err != nil {
+		return fmt.Errorf("get pin: %w", err)
+	}
+	pin.Server.PublicKey = p.PublicKey
+	if err := s.pins.Set(id, pin); err != nil {
+		return fmt.Errorf("set pin: %w", err)
+	}
+	delete(s.pending, id)
+
+	if c.status == AuthPendingTrust {
+		c.pin = pin
+		c.status = AuthConnected
+		c.conn.ResetConnectBackoff()
+	}
+	return nil
+}
 
+func (s *AuthService) RejectTrust(id string) error {
+	c, err := s.lookup(id)
+	if err != nil {
+		return fmt.Errorf("pending trust %q: %w", id, shared.ErrNotExist)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := s.takePendingTrust(id); !ok {
+		return fmt.Errorf("pending trust %q: %w", id, shared.ErrNotExist)
+	}
+	if c.status == AuthPendingTrust {
+		s.disconnect(c)
+	}
 	return nil
 }
 
-func BindClient[T any](s *AuthService, c func(grpc.ClientConnInterface) T) (T, error) {
-	cl := c(s.conn)
-	if s.status == AuthDisconnected {
-		return cl, client.ErrNoConn
+func (s *AuthService) takePendingTrust(id string) (client.PendingTrust, bool) {
+	s.trustMu.Lock()
+	defer s.trustMu.Unlock()
+	p, ok := s.pending[id]
+	delete(s.pending, id)
+	return p, ok
+}
+
+func (s *AuthService) pendingTrustError(id string) error {
+	p, _ := s.PendingTrust(id)
+	return fmt.Errorf("server key changed to %s: %w", p.Fingerprint, client.ErrUntrusted)
+}
+
+func BindClient[T any](s *AuthService, id string, c func(grpc.ClientConnInterface) T) (T, error) {
+	var zero T
+	conn, err := s.lookup(id)
+	if err != nil {
+		return zero, err
+	}
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	if conn.status == AuthDisconnected {
+		return zero, client.ErrNoConn
 	}
-	return cl, nil
+	return c(conn.conn), nil
 }
 
-func (s *AuthService) Session() (shared.Session, error) {
-	if s.status == AuthDisconnected {
+func (s *AuthService) Session(id string) (shared.Session, error) {
+	c, err := s.lookup(id)
+	if err != nil {
+		return shared.Session{}, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.status == AuthDisconnected {
 		return shared.Session{}, client.ErrNoConn
 	}
-	if s.status != AuthLoggedIn {
+	if c.status != AuthLoggedIn {
 		return shared.Session{}, client.ErrNoLoggedIn
 	}
-	if s.session == nil {
+	if c.session == nil {
 		panic("invariant violation: session status is active but struct is nil")
 	}
-	return *s.session, nil
+	return *c.session, nil
 }
 
-func (s *AuthService) Pin() (client.ConnPin, error) {
-	if s.status == AuthDisconnected {
+func (s *AuthService) Pin(id string) (client.ConnPin, error) {
+	c, err := s.lookup(id)
+	if err != nil {
+		return client.ConnPin{}, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.status == AuthDisconnected {
 		return client.ConnPin{}, client.ErrNoConn
 	}
-	return s.pin, nil
+	return c.pin, nil
 }
 
-func (s *AuthService) Status() AuthServiceStatus {
-	return s.status
+func (s *AuthService) Status(id string) AuthServiceStatus {
+	c, err := s.lookup(id)
+	if err != nil {
+		return AuthDisconnected
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.status
 }
 
-func (s *AuthService) connect(ctx context.Context, id string) error {
-	if s.status != AuthDisconnected {
+// LoggedIn returns the IDs of the connections with an active session.
+func (s *AuthService) LoggedIn() []string {
+	s.mu.Lock()
+	conns := make(map[string]*connection, len(s.conns))
+	maps.Copy(conns, s.conns)
+	s.mu.Unlock()
+
+	var ids []string
+	for id, c := range conns {
+		c.mu.Lock()
+		if c.status == AuthLoggedIn {
+			ids = append(ids, id)
+		}
+		c.mu.Unlock()
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// WithIdle runs fn while connection id is held disconnected, so that its
+// pin can be changed under it. The connection is dropped once fn succeeds,
+// since the pin it was made for is gone.
+func (s *AuthService) WithIdle(id string, fn func() error) error {
+	c, created := s.acquire(id)
+	defer c.mu.Unlock()
+	if c.status != AuthDisconnected {
+		return client.ErrConnActive
+	}
+	err := fn()
+	if created || err == nil {
+		s.forget(id, c)
+	}
+	return err
+}
+
+// acquire returns the connection of id locked, creating it if there is
+// none yet. created reports whether it did.
+func (s *AuthService) acquire(id string) (c *connection, created bool) {
+	for {
+		s.mu.Lock()
+		c, ok := s.conns[id]
+		if !ok {
+			c = &connection{}
+			s.conns[id] = c
+		}
+		s.mu.Unlock()
+
+		c.mu.Lock()
+		// The connection may have been forgotten while waiting for it.
+		s.mu.Lock()
+		current := s.conns[id] == c
+		s.mu.Unlock()
+		if current {
+			return c, !ok
+		}
+		c.mu.Unlock()
+	}
+}
+
+// forget drops the connection c of id, which must be held disconnected.
+// Whoever still holds it finds it logged out.
+func (s *AuthService) forget(id string, c *connection) {
+	c.relogin = false
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conns[id] == c {
+		delete(s.conns, id)
+	}
+}
+
+func (s *AuthService) lookup(id string) (*connection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.conns[id]
+	if !ok {
+		return nil, client.ErrNoConn
+	}
+	return c, nil
+}
+
+func (s *AuthService) connect(c *connection, id string) error {
+	if c.status != AuthDisconnected {
 		return client.ErrConn
 	}
 
@@ -224,77 +491,157 @@ func (s *AuthService) connect(ctx context.Context, id string) error {
 		return fmt.Errorf("get pin %q: %w", id, err)
 	}
 
-	config := &tls.Config{
-		VerifyPeerCertificate: s.verifyServerCertificate,
-		InsecureSkipVerify:    true,
-		NextProtos:            []string{"h2"},
-	}
-	creds := credentials.NewTLS(config)
-	conn, err := grpc.NewClient(
-		pin.Server.IPAddress,
-		grpc.WithTransportCredentials(creds),
-	)
+	conn, err := dialServer(pin.Server.Addr(), s.verifyServerCertificate(id))
 	if err != nil {
 		return fmt.Errorf("establish connection: %w", err)
 	}
 
-	s.conn = conn
-	s.pin = pin
-	s.status = AuthConnected
+	c.conn = conn
+	c.pin = pin
+	c.status = AuthConnected
 
 	return nil
 }
 
-func (s *AuthService) disconnect() {
-	s.status = AuthDisconnected
-	s.conn.Close()
+func (s *AuthService) disconnect(c *connection) {
+	c.status = AuthDisconnected
+	c.session = nil
+	c.conn.Close()
 }
 
-func (s *AuthService) verifyServerCertificate(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
-	pin, err := s.pins.Get(s.pin.ID)
+// applyKeyRollover asks the server behind the pending key for a rollover
+// statement signed by the pinned key, and moves the pin to the new key if
+// the statement holds.
+func (s *AuthService) applyKeyRollover(ctx context.Context, c *connection, p client.PendingTrust) error {
+	pin, err := s.pins.Get(p.ConnID)
 	if err != nil {
-		return fmt.Errorf("update pin %q: %w", s.pin.ID, err)
+		return fmt.Errorf("get pin %q: %w", p.ConnID, err)
 	}
 
-	tcpAddr, err := net.ResolveTCPAddr("tcp", pin.Server.IPAddress)
+	conn, err := dialServer(pin.Server.Addr(), func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		_, err := checkServerCertificate(rawCerts, pin.Server.Addr(), p.PublicKey)
+		return err
+	})
 	if err != nil {
-		return fmt.Errorf("resolve server tcp address: %w", err)
+		return fmt.Errorf("establish connection: %w", err)
+	}
+	defer conn.Close()
+
+	rep, err := gatewaypb.NewAuthServiceClient(conn).GetKeyRollover(ctx, &gatewaypb.GetKeyRolloverRequest{})
+	if err != nil {
+		return fmt.Errorf("request key rollover: %w", err)
+	}
+	r, err := pb.KeyRolloverFromPB(rep.Rollover)
+	if err != nil {
+		return fmt.Errorf("parse key rollover: %w", err)
+	}
+	if !r.OldKey.Equal(pin.Server.PublicKey) {
+		return fmt.Errorf("rollover is not signed by pinned key")
+	}
+	if !r.NewKey.Equal(p.PublicKey) {
+		return fmt.Errorf("rollover does not name presented key")
+	}
+	if err := r.Verify(time.Now()); err != nil {
+		return fmt.Errorf("verify rollover: %w", err)
+	}
+
+	pin.Server.PublicKey = r.NewKey
+	if err := s.pins.Set(pin.ID, pin); err != nil {
+		return fmt.Errorf("set pin: %w", err)
+	}
+	s.takePendingTrust(pin.ID)
+	if c.status != AuthDisconnected {
+		c.pin = pin
+		c.conn.ResetConnectBackoff()
+	}
+	return nil
+}
+
+func (s *AuthService) verifyServerCertificate(id string) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		pin, err := s.pins.Get(id)
+		if err != nil {
+			return fmt.Errorf("update pin %q: %w", id, err)
+		}
+
+		cert, err := checkServerCertificate(rawCerts, pin.Server.Addr(), pin.Server.PublicKey)
+		if errors.Is(err, errCertSignature) {
+			certKey := cert.PublicKey.(ed25519.PublicKey)
+			if !ed25519.Verify(certKey, cert.RawTBSCertificate, cert.Signature) {
+				return fmt.Errorf("certificate signature mismatch: not self-signed")
+			}
+			s.trustMu.Lock()
+			s.pending[id] = client.PendingTrust{
+				ConnID:      id,
+				PublicKey:   certKey,
+				Fingerprint: shared.KeyFingerprint(certKey),
+			}
+			s.trustMu.Unlock()
+			return fmt.Errorf("%w: %w", err, client.ErrUntrusted)
+		}
+		return err
+	}
+}
+
+var errCertSignature = errors.New("certificate signature mismatch")
+
+func dialServer(addr string, verify func([][]byte, [][]*x509.Certificate) error) (*grpc.ClientConn, error) {
+	config := &tls.Config{
+		VerifyPeerCertificate: verify,
+		InsecureSkipVerify:    true,
+		NextProtos:            []string{"h2"},
+	}
+	creds := credentials.NewTLS(config)
+	return grpc.NewClient(
+		addr,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(log.UnaryClientRequestIDInterceptor()),
+		grpc.WithChainStreamInterceptor(log.StreamClientRequestIDInterceptor()),
+	)
+}
+
+// checkServerCertificate verifies the leaf certificate against addr and
+// key. A certificate that passes every check but was not signed by key is
+// returned along with errCertSignature.
+func checkServerCertificate(rawCerts [][]byte, addr string, key ed25519.PublicKey) (*x509.Certificate, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("parse server address: %w", err)
 	}
 
 	cert, err := x509.ParseCertificate(rawCerts[0])
 	if err != nil {
-		return fmt.Errorf("parse certificate: %w", err)
+		return nil, fmt.Errorf("parse certificate: %w", err)
 	}
 
 	_, ok := cert.PublicKey.(ed25519.PublicKey)
 	if !ok {
-		return fmt.Errorf("bad certificate key format, must be ed25519")
+		return nil, fmt.Errorf("bad certificate key format, must be ed25519")
 	}
 
-	if err = cert.VerifyHostname(fmt.Sprintf("[%s]", tcpAddr.IP.String())); err != nil {
-		return fmt.Errorf("verify certificate hostname: %w", err)
+	if err = cert.VerifyHostname(host); err != nil {
+		return nil, fmt.Errorf("verify certificate hostname: %w", err)
 	}
 
 	now := time.Now()
 	if now.Before(cert.NotBefore) {
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"certificate expired, current time %s is before %s",
 			now.Format(time.RFC3339),
 			cert.NotBefore.Format(time.RFC3339),
 		)
 	}
 	if now.After(cert.NotAfter) {
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"certificate expired, current time %s is after %s",
 			now.Format(time.RFC3339),
 			cert.NotAfter.Format(time.RFC3339),
 		)
 	}
 
-	if ok = ed25519.Verify(pin.Server.PublicKey, cert.RawTBSCertificate, cert.Signature); !ok {
-		// TODO: User verification of mismatched signature
-		return fmt.Errorf("certificate signature mismatch: user verification unimplemented")
+	if len(key) != ed25519.PublicKeySize || !ed25519.Verify(key, cert.RawTBSCertificate, cert.Signature) {
+		return cert, errCertSignature
 	}
 
-	return nil
+	return cert, nil
 }
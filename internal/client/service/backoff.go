@@ -0,0 +1,22 @@
+package service
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	backoffBase = 500 * time.Millisecond
+	backoffMax  = 30 * time.Second
+)
+
+// backoffDelay returns the wait before reconnect attempt n, counted from 1.
+// It doubles per attempt up to backoffMax and is jittered down by up to
+// half so that clients dropped together do not return together.
+func backoffDelay(n int) time.Duration {
+	d := backoffMax
+	if n < 16 {
+		d = min(backoffBase<<(n-1), backoffMax)
+	}
+	return d/2 + rand.N(d/2+1)
+}
@@ -2,105 +2,664 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	gatewaypb "github.com/charadev96/gonec/gen/gateway"
 	sharedpb "github.com/charadev96/gonec/gen/shared"
+	client "github.com/charadev96/gonec/internal/client/domain"
 	shared "github.com/charadev96/gonec/internal/shared/domain"
 	pb "github.com/charadev96/gonec/internal/shared/pb"
 )
 
 type ChatService struct {
 	auth *AuthService
-}
+	msgs client.MessageRepository
 
-type MessageStream struct {
-	client grpc.ServerStreamingClient[sharedpb.Message]
+	mu      sync.Mutex
+	streams map[string]*chatStream
 }
 
-func NewChatService(a *AuthService) *ChatService {
+func NewChatService(a *AuthService, m client.MessageRepository) *ChatService {
 	return &ChatService{
-		auth: a,
+		auth:    a,
+		msgs:    m,
+		streams: make(map[string]*chatStream),
 	}
 }
 
-func (s *MessageStream) Next() (shared.Message, error) {
-	m, err := s.client.Recv()
+// Send sends str with the uploaded attachments to the user named by to,
+// either an ID or a name, as a reply to message replyTo unless it is zero,
+// and returns the message as delivered. It goes over the Chat stream of the
+// connection if Events has one open.
+func (s *ChatService) Send(ctx context.Context, connID string, to string, str string, attachments []uuid.UUID, replyTo uuid.UUID) (shared.Message, error) {
+	msg, err := s.sendChat(ctx, connID, to, str, attachments, replyTo)
+	if errors.Is(err, errNoChat) {
+		msg, err = s.sendUnary(ctx, connID, to, str, attachments, replyTo)
+	}
 	if err != nil {
 		return shared.Message{}, err
 	}
-	return pb.MessageFromPB(m)
+
+	_, err = s.msgs.Save(ctx, client.StoredMessage{
+		ConnID:    connID,
+		Peer:      msg.Recipient,
+		Direction: client.DirectionSent,
+		Content:   str,
+		CreatedAt: sentAt(msg),
+		MessageID: msg.ID,
+		ReplyTo:   msg.ReplyTo,
+		ThreadID:  msg.ThreadID,
+	})
+	if err != nil {
+		return shared.Message{}, fmt.Errorf("record sent message: %w", err)
+	}
+
+	return msg, nil
+}
+
+func (s *ChatService) sendChat(ctx context.Context, connID string, to string, str string, attachments []uuid.UUID, replyTo uuid.UUID) (shared.Message, error) {
+	s.mu.Lock()
+	cs, ok := s.streams[connID]
+	s.mu.Unlock()
+	if !ok {
+		return shared.Message{}, errNoChat
+	}
+	return cs.Send(ctx, to, str, attachments, replyTo)
 }
 
-func (s *ChatService) Send(ctx context.Context, to uuid.UUID, str string) error {
-	cl, err := BindClient(s.auth, gatewaypb.NewChatServiceClient)
+func (s *ChatService) sendUnary(ctx context.Context, connID string, to string, str string, attachments []uuid.UUID, replyTo uuid.UUID) (shared.Message, error) {
+	cl, err := BindClient(s.auth, connID, gatewaypb.NewChatServiceClient)
 	if err != nil {
-		return err
+		return shared.Message{}, err
 	}
 
-	session, err := s.auth.Session()
+	session, err := s.auth.Session(connID)
 	if err != nil {
-		return fmt.Errorf("get active session: %w", err)
+		return shared.Message{}, fmt.Errorf("get active session: %w", err)
+	}
+
+	reply, err := cl.Send(ctx, &gatewaypb.SendRequest{
+		Auth:          pb.SessionToPB(session),
+		Recipient:     to,
+		Content:       str,
+		AttachmentIds: uuidsToPB(attachments),
+		ReplyTo:       optionalUUIDToPB(replyTo),
+	})
+	if err != nil {
+		return shared.Message{}, fmt.Errorf("request send: %w", err)
 	}
 
-	_, err = cl.Send(ctx, &gatewaypb.SendRequest{
+	// Servers predating names leave the recipient out, to was an ID then,
+	// and those predating message IDs leave the ID out.
+	msg := shared.Message{
+		Sender:  session.UserID,
+		Content: str,
+		SentAt:  time.Now(),
+		ReplyTo: replyTo,
+	}
+	recipient := reply.RecipientId
+	if recipient == "" {
+		recipient = to
+	}
+	if msg.Recipient, err = pb.UUIDFromPB(recipient); err != nil {
+		return shared.Message{}, fmt.Errorf("parse recipient: %w", err)
+	}
+	if reply.MessageId != "" {
+		if msg.ID, err = pb.UUIDFromPB(reply.MessageId); err != nil {
+			return shared.Message{}, fmt.Errorf("parse message id: %w", err)
+		}
+	}
+	if reply.ThreadId != "" {
+		if msg.ThreadID, err = pb.UUIDFromPB(reply.ThreadId); err != nil {
+			return shared.Message{}, fmt.Errorf("parse thread id: %w", err)
+		}
+	}
+	return msg, nil
+}
+
+// EditMessage replaces the content of a message sent on the connection,
+// on the server and in the local history.
+func (s *ChatService) EditMessage(ctx context.Context, connID string, id uuid.UUID, str string) (shared.MessageEdit, error) {
+	cl, err := BindClient(s.auth, connID, gatewaypb.NewChatServiceClient)
+	if err != nil {
+		return shared.MessageEdit{}, err
+	}
+	session, err := s.auth.Session(connID)
+	if err != nil {
+		return shared.MessageEdit{}, fmt.Errorf("get active session: %w", err)
+	}
+
+	reply, err := cl.EditMessage(ctx, &gatewaypb.EditMessageRequest{
 		Auth:      pb.SessionToPB(session),
-		Recipient: to.String(),
+		MessageId: id.String(),
 		Content:   str,
 	})
 	if err != nil {
-		return fmt.Errorf("request send: %w", err)
+		return shared.MessageEdit{}, fmt.Errorf("request edit: %w", err)
+	}
+	edit, err := pb.MessageEditFromPB(reply.Edit)
+	if err != nil {
+		return shared.MessageEdit{}, fmt.Errorf("parse edit: %w", err)
 	}
 
-	return nil
+	err = s.msgs.Edit(ctx, connID, edit)
+	if err != nil && !errors.Is(err, shared.ErrNotExist) {
+		return shared.MessageEdit{}, fmt.Errorf("record message edit: %w", err)
+	}
+	return edit, nil
 }
 
-func (s *ChatService) Listen(ctx context.Context) (<-chan shared.Packet[shared.Message], error) {
-	cl, err := BindClient(s.auth, gatewaypb.NewChatServiceClient)
+// DeleteMessage retracts a message sent on the connection, on the server
+// and in the local history.
+func (s *ChatService) DeleteMessage(ctx context.Context, connID string, id uuid.UUID) (shared.MessageDelete, error) {
+	cl, err := BindClient(s.auth, connID, gatewaypb.NewChatServiceClient)
 	if err != nil {
-		return nil, err
+		return shared.MessageDelete{}, err
+	}
+	session, err := s.auth.Session(connID)
+	if err != nil {
+		return shared.MessageDelete{}, fmt.Errorf("get active session: %w", err)
 	}
 
-	session, err := s.auth.Session()
+	reply, err := cl.DeleteMessage(ctx, &gatewaypb.DeleteMessageRequest{
+		Auth:      pb.SessionToPB(session),
+		MessageId: id.String(),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("get active session: %w", err)
+		return shared.MessageDelete{}, fmt.Errorf("request delete: %w", err)
+	}
+	del, err := pb.MessageDeleteFromPB(reply.Delete)
+	if err != nil {
+		return shared.MessageDelete{}, fmt.Errorf("parse delete: %w", err)
 	}
 
-	ctxLn, cancel := context.WithCancel(ctx)
+	err = s.msgs.Delete(ctx, connID, del)
+	if err != nil && !errors.Is(err, shared.ErrNotExist) {
+		return shared.MessageDelete{}, fmt.Errorf("record message deletion: %w", err)
+	}
+	return del, nil
+}
 
-	ch, err := cl.Listen(ctxLn, &gatewaypb.ListenRequest{
-		Auth: pb.SessionToPB(session),
+// React puts emoji on a message sent or received on the connection, or
+// takes it off if remove is set, on the server and in the local history.
+func (s *ChatService) React(ctx context.Context, connID string, id uuid.UUID, emoji string, remove bool) (shared.Reaction, error) {
+	cl, err := BindClient(s.auth, connID, gatewaypb.NewChatServiceClient)
+	if err != nil {
+		return shared.Reaction{}, err
+	}
+	session, err := s.auth.Session(connID)
+	if err != nil {
+		return shared.Reaction{}, fmt.Errorf("get active session: %w", err)
+	}
+
+	reply, err := cl.React(ctx, &gatewaypb.ReactRequest{
+		Auth:      pb.SessionToPB(session),
+		MessageId: id.String(),
+		Emoji:     emoji,
+		Remove:    remove,
 	})
 	if err != nil {
-		cancel()
-		return nil, fmt.Errorf("request listen: %w", err)
+		return shared.Reaction{}, fmt.Errorf("request react: %w", err)
+	}
+	r, err := pb.ReactionFromPB(reply.Reaction)
+	if err != nil {
+		return shared.Reaction{}, fmt.Errorf("parse reaction: %w", err)
 	}
 
-	ln := make(chan shared.Packet[shared.Message])
+	err = s.msgs.React(ctx, connID, r)
+	if err != nil && !errors.Is(err, shared.ErrNotExist) {
+		return shared.Reaction{}, fmt.Errorf("record reaction: %w", err)
+	}
+	return r, nil
+}
 
+// ListThread lists the replies in the thread of message id on the server of
+// the connection, oldest first, starting after the reply after unless it is
+// zero.
+func (s *ChatService) ListThread(ctx context.Context, connID string, id uuid.UUID, after uuid.UUID, limit int) (shared.MessagePage, error) {
+	cl, err := BindClient(s.auth, connID, gatewaypb.NewChatServiceClient)
+	if err != nil {
+		return shared.MessagePage{}, err
+	}
+	session, err := s.auth.Session(connID)
+	if err != nil {
+		return shared.MessagePage{}, fmt.Errorf("get active session: %w", err)
+	}
+
+	reply, err := cl.ListThread(ctx, &gatewaypb.ListThreadRequest{
+		Auth:      pb.SessionToPB(session),
+		MessageId: id.String(),
+		Limit:     uint32(max(limit, 0)),
+		Cursor:    optionalUUIDToPB(after),
+	})
+	if err != nil {
+		return shared.MessagePage{}, fmt.Errorf("request thread: %w", err)
+	}
+	page := shared.MessagePage{Messages: make([]shared.Message, 0, len(reply.Messages))}
+	for _, m := range reply.Messages {
+		msg, err := pb.MessageFromPB(m)
+		if err != nil {
+			return shared.MessagePage{}, fmt.Errorf("parse message: %w", err)
+		}
+		page.Messages = append(page.Messages, msg)
+	}
+	if reply.Cursor != "" {
+		if page.Cursor, err = pb.UUIDFromPB(reply.Cursor); err != nil {
+			return shared.MessagePage{}, fmt.Errorf("parse cursor: %w", err)
+		}
+	}
+	return page, nil
+}
+
+func (s *ChatService) LookupUser(ctx context.Context, connID string, ref string) (shared.UserIdentity, error) {
+	cl, err := BindClient(s.auth, connID, gatewaypb.NewChatServiceClient)
+	if err != nil {
+		return shared.UserIdentity{}, err
+	}
+
+	req := &gatewaypb.LookupUserRequest{User: ref}
+	if session, err := s.auth.Session(connID); err == nil {
+		req.Auth = pb.SessionToPB(session)
+	}
+	reply, err := cl.LookupUser(ctx, req)
+	if err != nil {
+		return shared.UserIdentity{}, fmt.Errorf("request lookup: %w", err)
+	}
+	return pb.UserIdentityFromPB(reply.User)
+}
+
+func (s *ChatService) History(ctx context.Context, q client.MessageListQuery) (client.MessageList, error) {
+	return s.msgs.List(ctx, q)
+}
+
+func (s *ChatService) Search(ctx context.Context, q client.MessageSearchQuery) (client.MessageList, error) {
+	return s.msgs.Search(ctx, q)
+}
+
+// Events merges the event streams of the given connections, or of every
+// logged in connection if none are given, asking each for the presence of
+// the users in presence. Streams broken by a transport failure are resumed
+// after logging in again, which is reported as connection events on the
+// merged stream; it ends when ctx is done, on the first other error, or once
+// the user has logged out of every connection.
+func (s *ChatService) Events(ctx context.Context, presence []uuid.UUID, connIDs ...string) (<-chan shared.Packet[client.ConnUpdate], error) {
+	if len(connIDs) == 0 {
+		connIDs = s.auth.LoggedIn()
+	}
+	if len(connIDs) == 0 {
+		return nil, client.ErrNoLoggedIn
+	}
+
+	ctxLn, cancel := context.WithCancel(ctx)
+
+	streams := make([]*chatStream, len(connIDs))
+	sessions := make([]shared.Session, len(connIDs))
+	for i, id := range connIDs {
+		st, session, err := s.open(ctxLn, id, presence)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("listen on %q: %w", id, err)
+		}
+		streams[i] = st
+		sessions[i] = session
+	}
+
+	ln := make(chan shared.Packet[client.ConnUpdate])
+	var once sync.Once
+	fail := func(err error) {
+		once.Do(func() {
+			cancel()
+			select {
+			case ln <- shared.Packet[client.ConnUpdate]{Err: err}:
+			case <-ctx.Done():
+			}
+		})
+	}
+	emit := func(u client.ConnUpdate) bool {
+		select {
+		case ln <- shared.Packet[client.ConnUpdate]{Msg: u}:
+			return true
+		case <-ctxLn.Done():
+			return false
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i, id := range connIDs {
+		wg.Go(func() {
+			if err := s.follow(ctxLn, id, presence, streams[i], sessions[i], emit); err != nil {
+				fail(err)
+			}
+		})
+	}
 	go func() {
-		defer cancel()
+		wg.Wait()
+		fail(client.ErrNoLoggedIn)
+	}()
 
+	return ln, nil
+}
+
+// Listen is Events for messages only.
+func (s *ChatService) Listen(ctx context.Context, connIDs ...string) (<-chan shared.Packet[client.ConnMessage], error) {
+	events, err := s.Events(ctx, nil, connIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	ln := make(chan shared.Packet[client.ConnMessage])
+
+	go func() {
+		defer close(ln)
 		for {
-			msg, err := ch.Recv()
-			if err != nil {
-				ln <- shared.Packet[shared.Message]{Err: err}
+			var pck shared.Packet[client.ConnUpdate]
+			select {
+			case pck = <-events:
+			case <-ctx.Done():
 				return
 			}
 
-			m, err := pb.MessageFromPB(msg)
-			if err != nil {
-				ln <- shared.Packet[shared.Message]{Err: err}
+			var m client.ConnMessage
+			if pck.Err == nil {
+				u := pck.Msg
+				if u.Conn == nil && u.Event.Message == nil {
+					continue
+				}
+				m = client.ConnMessage{ConnID: u.ConnID, Event: u.Conn}
+				if u.Event.Message != nil {
+					m.Message = *u.Event.Message
+				}
+			}
+
+			select {
+			case ln <- shared.Packet[client.ConnMessage]{Msg: m, Err: pck.Err}:
+			case <-ctx.Done():
+				return
+			}
+			if pck.Err != nil {
 				return
 			}
+		}
+	}()
+
+	return ln, nil
+}
+
+// follow forwards the upstream events of connection id until ctx is done,
+// resuming the stream after transport failures. It returns nil if the user
+// logs out of the connection.
+func (s *ChatService) follow(
+	ctx context.Context,
+	id string,
+	presence []uuid.UUID,
+	st *chatStream,
+	session shared.Session,
+	emit func(client.ConnUpdate) bool,
+) error {
+	s.register(id, st)
+	defer func() { s.unregister(id, st) }()
+
+	for {
+		e, err := st.Next()
+		if err == nil {
+			if err := s.record(ctx, id, e); err != nil {
+				return err
+			}
+			if !emit(client.ConnUpdate{ConnID: id, Event: e}) {
+				return context.Cause(ctx)
+			}
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+		if !reconnectable(err) {
+			return fmt.Errorf("listen on %q: %w", id, err)
+		}
+
+		s.unregister(id, st)
+		st, session, err = s.resume(ctx, id, presence, session, emit)
+		if errors.Is(err, client.ErrNoLoggedIn) {
+			emit(client.ConnUpdate{ConnID: id, Conn: &client.ConnEvent{State: client.ConnDisconnected}})
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.register(id, st)
+	}
+}
+
+// register makes st the stream that sends on connection id go over.
+func (s *ChatService) register(id string, st *chatStream) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.streams[id] = st
+}
+
+func (s *ChatService) unregister(id string, st *chatStream) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.streams[id] == st {
+		delete(s.streams, id)
+	}
+}
+
+// record keeps received messages in the local history, and applies edits
+// and deletions to it. Changes to messages it does not have are dropped.
+func (s *ChatService) record(ctx context.Context, id string, e shared.Event) error {
+	switch {
+	case e.Message != nil:
+		_, err := s.msgs.Save(ctx, client.StoredMessage{
+			ConnID:    id,
+			Peer:      e.Message.Sender,
+			Direction: client.DirectionReceived,
+			Content:   e.Message.Content,
+			CreatedAt: sentAt(*e.Message),
+			MessageID: e.Message.ID,
+			ReplyTo:   e.Message.ReplyTo,
+			ThreadID:  e.Message.ThreadID,
+		})
+		if err != nil {
+			return fmt.Errorf("record received message: %w", err)
+		}
+	case e.Edit != nil:
+		err := s.msgs.Edit(ctx, id, *e.Edit)
+		if err != nil && !errors.Is(err, shared.ErrNotExist) {
+			return fmt.Errorf("record message edit: %w", err)
+		}
+	case e.Delete != nil:
+		err := s.msgs.Delete(ctx, id, *e.Delete)
+		if err != nil && !errors.Is(err, shared.ErrNotExist) {
+			return fmt.Errorf("record message deletion: %w", err)
+		}
+	case e.Reaction != nil:
+		err := s.msgs.React(ctx, id, *e.Reaction)
+		if err != nil && !errors.Is(err, shared.ErrNotExist) {
+			return fmt.Errorf("record reaction: %w", err)
+		}
+	}
+	return nil
+}
+
+// resume logs in to connection id again and reopens its upstream stream,
+// backing off between failed attempts until one succeeds, ctx is done or
+// logging in fails for a reason retrying cannot cure, such as a logout.
+func (s *ChatService) resume(
+	ctx context.Context,
+	id string,
+	presence []uuid.UUID,
+	stale shared.Session,
+	emit func(client.ConnUpdate) bool,
+) (*chatStream, shared.Session, error) {
+	for attempt := 1; ; attempt++ {
+		err := s.auth.Relogin(ctx, id, stale)
+		if err != nil && !reconnectable(err) {
+			return nil, shared.Session{}, fmt.Errorf("log in again: %w", err)
+		}
+		if err == nil {
+			var st *chatStream
+			var session shared.Session
+			st, session, err = s.open(ctx, id, presence)
+			if err == nil {
+				ev := &client.ConnEvent{State: client.ConnConnected, Attempt: attempt}
+				emit(client.ConnUpdate{ConnID: id, Conn: ev})
+				return st, session, nil
+			}
+		}
+
+		ev := &client.ConnEvent{State: client.ConnReconnecting, Attempt: attempt, Err: err}
+		if !emit(client.ConnUpdate{ConnID: id, Conn: ev}) {
+			return nil, shared.Session{}, context.Cause(ctx)
+		}
+
+		t := time.NewTimer(backoffDelay(attempt))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, shared.Session{}, context.Cause(ctx)
+		case <-t.C:
+		}
+	}
+}
+
+// open opens the upstream of connection connID, a Chat stream that falls
+// back to Events on servers without one.
+func (s *ChatService) open(ctx context.Context, connID string, presence []uuid.UUID) (*chatStream, shared.Session, error) {
+	cl, err := BindClient(s.auth, connID, gatewaypb.NewChatServiceClient)
+	if err != nil {
+		return nil, shared.Session{}, err
+	}
+
+	session, err := s.auth.Session(connID)
+	if err != nil {
+		return nil, shared.Session{}, fmt.Errorf("get active session: %w", err)
+	}
+
+	chat, err := cl.Chat(ctx)
+	if err != nil {
+		return nil, shared.Session{}, fmt.Errorf("request chat: %w", err)
+	}
+	err = chat.Send(&gatewaypb.ChatRequest{
+		Payload: &gatewaypb.ChatRequest_Open{Open: &gatewaypb.ChatOpen{
+			Auth:            pb.SessionToPB(session),
+			PresenceUserIds: uuidsToPB(presence),
+		}},
+	})
+	// On io.EOF the server has ended the stream already, and why is told
+	// by the next Recv.
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, shared.Session{}, fmt.Errorf("request chat: %w", err)
+	}
 
+	fallback := func() (grpc.ServerStreamingClient[sharedpb.Event], error) {
+		return cl.Events(ctx, &gatewaypb.EventsRequest{
+			Auth:            pb.SessionToPB(session),
+			PresenceUserIds: uuidsToPB(presence),
+		})
+	}
+	return newChatStream(chat, fallback), session, nil
+}
+
+// sentAt returns when the server took m, or now for servers that leave it
+// out.
+func sentAt(m shared.Message) time.Time {
+	if m.SentAt.IsZero() {
+		return time.Now()
+	}
+	return m.SentAt
+}
+
+// reconnectable reports whether err is a failure of the transport or of the
+// session that logging in again may cure. A closed transport shows up as
+// Canceled, which is also how a logout ends the stream.
+func reconnectable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Unauthenticated, codes.Aborted, codes.Canceled:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *ChatService) Presence(ctx context.Context, connID string, ids []uuid.UUID) ([]shared.Presence, error) {
+	cl, err := BindClient(s.auth, connID, gatewaypb.NewChatServiceClient)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.auth.Session(connID)
+	if err != nil {
+		return nil, fmt.Errorf("get active session: %w", err)
+	}
+
+	reply, err := cl.GetPresence(ctx, &gatewaypb.GetPresenceRequest{
+		Auth:    pb.SessionToPB(session),
+		UserIds: uuidsToPB(ids),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("request presence: %w", err)
+	}
+
+	ps := make([]shared.Presence, 0, len(reply.Presences))
+	for _, p := range reply.Presences {
+		presence, err := pb.PresenceFromPB(p)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, presence)
+	}
+	return ps, nil
+}
+
+// WatchPresence streams the presence of ids on connection connID, starting
+// with their current state. It ends with the upstream stream.
+func (s *ChatService) WatchPresence(ctx context.Context, connID string, ids []uuid.UUID) (<-chan shared.Packet[shared.Presence], error) {
+	cl, err := BindClient(s.auth, connID, gatewaypb.NewChatServiceClient)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.auth.Session(connID)
+	if err != nil {
+		return nil, fmt.Errorf("get active session: %w", err)
+	}
+
+	st, err := cl.WatchPresence(ctx, &gatewaypb.WatchPresenceRequest{
+		Auth:    pb.SessionToPB(session),
+		UserIds: uuidsToPB(ids),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("request watch presence: %w", err)
+	}
+
+	ln := make(chan shared.Packet[shared.Presence])
+
+	go func() {
+		defer close(ln)
+		for {
+			var pck shared.Packet[shared.Presence]
+			p, err := st.Recv()
+			if err == nil {
+				pck.Msg, err = pb.PresenceFromPB(p)
+			}
+			pck.Err = err
 			select {
-			case ln <- shared.Packet[shared.Message]{Msg: m}:
+			case ln <- pck:
 			case <-ctx.Done():
-				ln <- shared.Packet[shared.Message]{Err: context.Cause(ctx)}
+				return
+			}
+			if pck.Err != nil {
 				return
 			}
 		}
@@ -108,3 +667,106 @@ func (s *ChatService) Listen(ctx context.Context) (<-chan shared.Packet[shared.M
 
 	return ln, nil
 }
+
+func (s *ChatService) SetPresenceHidden(ctx context.Context, connID string, hidden bool) error {
+	cl, err := BindClient(s.auth, connID, gatewaypb.NewChatServiceClient)
+	if err != nil {
+		return err
+	}
+
+	session, err := s.auth.Session(connID)
+	if err != nil {
+		return fmt.Errorf("get active session: %w", err)
+	}
+
+	_, err = cl.SetPresenceHidden(ctx, &gatewaypb.SetPresenceHiddenRequest{
+		Auth:   pb.SessionToPB(session),
+		Hidden: hidden,
+	})
+	if err != nil {
+		return fmt.Errorf("request set presence hidden: %w", err)
+	}
+	return nil
+}
+
+func (s *ChatService) BlockUser(ctx context.Context, connID string, ref string) (shared.Block, error) {
+	cl, err := BindClient(s.auth, connID, gatewaypb.NewChatServiceClient)
+	if err != nil {
+		return shared.Block{}, err
+	}
+	session, err := s.auth.Session(connID)
+	if err != nil {
+		return shared.Block{}, fmt.Errorf("get active session: %w", err)
+	}
+
+	reply, err := cl.BlockUser(ctx, &gatewaypb.BlockUserRequest{
+		Auth: pb.SessionToPB(session),
+		User: ref,
+	})
+	if err != nil {
+		return shared.Block{}, fmt.Errorf("request block user: %w", err)
+	}
+	return pb.BlockFromPB(reply.Block)
+}
+
+func (s *ChatService) UnblockUser(ctx context.Context, connID string, id uuid.UUID) error {
+	cl, err := BindClient(s.auth, connID, gatewaypb.NewChatServiceClient)
+	if err != nil {
+		return err
+	}
+	session, err := s.auth.Session(connID)
+	if err != nil {
+		return fmt.Errorf("get active session: %w", err)
+	}
+
+	_, err = cl.UnblockUser(ctx, &gatewaypb.UnblockUserRequest{
+		Auth:   pb.SessionToPB(session),
+		UserId: id.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("request unblock user: %w", err)
+	}
+	return nil
+}
+
+func (s *ChatService) ListBlocks(ctx context.Context, connID string) ([]shared.Block, error) {
+	cl, err := BindClient(s.auth, connID, gatewaypb.NewChatServiceClient)
+	if err != nil {
+		return nil, err
+	}
+	session, err := s.auth.Session(connID)
+	if err != nil {
+		return nil, fmt.Errorf("get active session: %w", err)
+	}
+
+	reply, err := cl.ListBlocks(ctx, &gatewaypb.ListBlocksRequest{
+		Auth: pb.SessionToPB(session),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("request list blocks: %w", err)
+	}
+	bs := make([]shared.Block, 0, len(reply.Blocks))
+	for _, b := range reply.Blocks {
+		block, err := pb.BlockFromPB(b)
+		if err != nil {
+			return nil, fmt.Errorf("parse block: %w", err)
+		}
+		bs = append(bs, block)
+	}
+	return bs, nil
+}
+
+func optionalUUIDToPB(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
+
+func uuidsToPB(ids []uuid.UUID) []string {
+	strs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		strs = append(strs, pb.UUIDToPB(id))
+	}
+	return strs
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	client "github.com/charadev96/gonec/internal/client/domain"
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+)
+
+type PinService struct {
+	auth    *AuthService
+	pins    client.ConnPinRepository
+	msgs    client.MessageRepository
+	bundles client.PinBundleCodec
+}
+
+func NewPinService(
+	a *AuthService,
+	p client.ConnPinRepository,
+	m client.MessageRepository,
+	b client.PinBundleCodec,
+) *PinService {
+	return &PinService{
+		auth:    a,
+		pins:    p,
+		msgs:    m,
+		bundles: b,
+	}
+}
+
+func (s *PinService) List() ([]client.ConnPin, error) {
+	return s.pins.List()
+}
+
+// Rename moves the pin and the message history of connection from to the
+// ID to. Neither may be connected meanwhile.
+func (s *PinService) Rename(ctx context.Context, from, to string) error {
+	if to == "" {
+		return shared.NewError(shared.ErrInvalid, "empty connection id")
+	}
+	if from == to {
+		return nil
+	}
+	return s.withIdle([]string{from, to}, func() error {
+		if err := s.pins.Rename(from, to); err != nil {
+			return fmt.Errorf("rename pin: %w", err)
+		}
+		if err := s.msgs.RenameConn(ctx, from, to); err != nil {
+			// Put the pin back with the history it belongs to.
+			if rerr := s.pins.Rename(to, from); rerr != nil {
+				return fmt.Errorf("rename message history: %w, restore pin: %w", err, rerr)
+			}
+			return fmt.Errorf("rename message history: %w", err)
+		}
+		s.auth.takePendingTrust(from)
+		return nil
+	})
+}
+
+func (s *PinService) Delete(id string) error {
+	return s.auth.WithIdle(id, func() error {
+		if err := s.pins.Delete(id); err != nil {
+			return fmt.Errorf("delete pin: %w", err)
+		}
+		s.auth.takePendingTrust(id)
+		return nil
+	})
+}
+
+// Export seals the pins with the given IDs, or all of them if none are
+// given, into a bundle encrypted with passphrase.
+func (s *PinService) Export(passphrase string, ids ...string) ([]byte, error) {
+	var pins []client.ConnPin
+	if len(ids) == 0 {
+		all, err := s.pins.List()
+		if err != nil {
+			return nil, fmt.Errorf("list pins: %w", err)
+		}
+		pins = all
+	}
+	for _, id := range ids {
+		pin, err := s.pins.Get(id)
+		if err != nil {
+			return nil, fmt.Errorf("get pin: %w", err)
+		}
+		pins = append(pins, pin)
+	}
+	return s.bundles.Seal(pins, passphrase)
+}
+
+// Import adds the pins of bundle, resolving pins whose ID is taken by
+// policy. Nothing is imported if any pin cannot be, as with ConflictFail
+// and a taken ID.
+func (s *PinService) Import(bundle []byte, passphrase string, policy client.ConflictPolicy) ([]client.ImportResult, error) {
+	pins, err := s.bundles.Open(bundle, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(pins))
+	for _, p := range pins {
+		if p.ID == "" {
+			return nil, shared.NewError(shared.ErrInvalid, "empty connection id in bundle")
+		}
+		ids = append(ids, p.ID)
+	}
+
+	// Renamed pins go to IDs no pin had, which no connection can be using.
+	var results []client.ImportResult
+	err = s.withIdle(ids, func() error {
+		return s.pins.Merge(func(taken map[string]bool) ([]client.ConnPin, error) {
+			res, imported, err := resolveImport(pins, taken, policy)
+			results = res
+			return imported, err
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("set pins: %w", err)
+	}
+	return results, nil
+}
+
+// resolveImport applies policy to the pins whose ID is taken, and returns
+// what becomes of each pin along with the pins to store.
+func resolveImport(
+	pins []client.ConnPin,
+	taken map[string]bool,
+	policy client.ConflictPolicy,
+) ([]client.ImportResult, []client.ConnPin, error) {
+	results := make([]client.ImportResult, 0, len(pins))
+	var imported []client.ConnPin
+	for _, p := range pins {
+		res := client.ImportResult{ConnID: p.ID, ImportedAs: p.ID}
+		if taken[p.ID] {
+			switch policy {
+			case client.ConflictFail:
+				return nil, nil, fmt.Errorf("pin %q: %w", p.ID, shared.ErrExist)
+			case client.ConflictSkip:
+				res.ImportedAs = ""
+				res.Skipped = true
+				results = append(results, res)
+				continue
+			case client.ConflictRename:
+				res.ImportedAs = freeConnID(p.ID, taken)
+			}
+		}
+
+		p.ID = res.ImportedAs
+		taken[p.ID] = true
+		imported = append(imported, p)
+		results = append(results, res)
+	}
+	return results, imported, nil
+}
+
+// withIdle runs fn while the connections of ids are all held disconnected.
+// They are taken in a fixed order so that concurrent callers cannot
+// deadlock.
+func (s *PinService) withIdle(ids []string, fn func() error) error {
+	ids = slices.Compact(slices.Sorted(slices.Values(ids)))
+	var hold func(ids []string) error
+	hold = func(ids []string) error {
+		if len(ids) == 0 {
+			return fn()
+		}
+		return s.auth.WithIdle(ids[0], func() error {
+			return hold(ids[1:])
+		})
+	}
+	return hold(ids)
+}
+
+func freeConnID(id string, taken map[string]bool) string {
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s-%d", id, n)
+		if !taken[candidate] {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gatewaypb "github.com/charadev96/gonec/gen/gateway"
+	sharedpb "github.com/charadev96/gonec/gen/shared"
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+	"github.com/charadev96/gonec/internal/shared/handler"
+	pb "github.com/charadev96/gonec/internal/shared/pb"
+)
+
+var errNoChat = errors.New("no open chat stream")
+
+// chatStream is the upstream of a connection: a Chat stream, or an Events
+// stream on servers without Chat. Sends go over the Chat stream while it is
+// open.
+type chatStream struct {
+	chat     grpc.BidiStreamingClient[gatewaypb.ChatRequest, gatewaypb.ChatReply]
+	events   grpc.ServerStreamingClient[sharedpb.Event]
+	fallback func() (grpc.ServerStreamingClient[sharedpb.Event], error)
+
+	mu      sync.Mutex
+	next    uint64
+	pending map[uint64]chan *gatewaypb.ChatAck
+	err     error
+
+	// sendMu serializes writes to the Chat stream apart from mu, which Next
+	// needs to hand out acks while a write blocks.
+	sendMu sync.Mutex
+}
+
+func newChatStream(
+	chat grpc.BidiStreamingClient[gatewaypb.ChatRequest, gatewaypb.ChatReply],
+	fallback func() (grpc.ServerStreamingClient[sharedpb.Event], error),
+) *chatStream {
+	return &chatStream{
+		chat:     chat,
+		fallback: fallback,
+		pending:  make(map[uint64]chan *gatewaypb.ChatAck),
+	}
+}
+
+// Next returns the next event, handing acks on the way to their senders.
+func (c *chatStream) Next() (shared.Event, error) {
+	for {
+		if c.events != nil {
+			ev, err := c.events.Recv()
+			if err != nil {
+				return shared.Event{}, err
+			}
+			return pb.EventFromPB(ev)
+		}
+
+		r, err := c.chat.Recv()
+		if err != nil {
+			c.close(err)
+			if status.Code(err) != codes.Unimplemented {
+				return shared.Event{}, err
+			}
+			if c.events, err = c.fallback(); err != nil {
+				return shared.Event{}, fmt.Errorf("request events: %w", err)
+			}
+			continue
+		}
+
+		switch p := r.Payload.(type) {
+		case *gatewaypb.ChatReply_Event:
+			return pb.EventFromPB(p.Event)
+		case *gatewaypb.ChatReply_Ack:
+			c.ack(p.Ack)
+		}
+	}
+}
+
+// Send sends str with attachments to to over the Chat stream, as a reply to
+// replyTo unless it is zero, and waits for its ack. It fails with errNoChat
+// if the stream is not open, before sending anything.
+func (c *chatStream) Send(ctx context.Context, to string, str string, attachments []uuid.UUID, replyTo uuid.UUID) (shared.Message, error) {
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return shared.Message{}, errNoChat
+	}
+	c.next++
+	id := c.next
+	ch := make(chan *gatewaypb.ChatAck, 1)
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	c.sendMu.Lock()
+	err := c.chat.Send(&gatewaypb.ChatRequest{
+		Payload: &gatewaypb.ChatRequest_Send{Send: &gatewaypb.ChatSend{
+			Id:            id,
+			Recipient:     to,
+			Content:       str,
+			AttachmentIds: uuidsToPB(attachments),
+			ReplyTo:       optionalUUIDToPB(replyTo),
+		}},
+	})
+	c.sendMu.Unlock()
+	if err != nil {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+		return shared.Message{}, fmt.Errorf("request send: %w", err)
+	}
+
+	select {
+	case ack, ok := <-ch:
+		if !ok {
+			err := c.closed()
+			// The server has no Chat, so it did not take the message.
+			if status.Code(err) == codes.Unimplemented {
+				return shared.Message{}, errNoChat
+			}
+			return shared.Message{}, fmt.Errorf("request send: %w", err)
+		}
+		if ack.Code != uint32(codes.OK) {
+			// The server made the error safe to show before acking.
+			st := handler.NewStatus(codes.Code(ack.Code), ack.Reason, ack.Error)
+			return shared.Message{}, fmt.Errorf("request send: %w", st.Err())
+		}
+		return pb.MessageFromPB(ack.Message)
+	case <-ctx.Done():
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+		return shared.Message{}, context.Cause(ctx)
+	}
+}
+
+func (c *chatStream) ack(a *gatewaypb.ChatAck) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if ch, ok := c.pending[a.Id]; ok {
+		delete(c.pending, a.Id)
+		ch <- a
+	}
+}
+
+// close fails the sends waiting for an ack with err, and any later ones
+// with errNoChat.
+func (c *chatStream) close(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return
+	}
+	c.err = err
+	for id, ch := range c.pending {
+		delete(c.pending, id)
+		close(ch)
+	}
+}
+
+func (c *chatStream) closed() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const certCheckInterval = time.Hour
+
+// CertificateTemplate returns a template for the gateway certificate valid
+// for lifetime from now, with a SAN for each of hosts. Hosts are IP
+// addresses or DNS names, without ports.
+func CertificateTemplate(hosts []string, lifetime time.Duration) x509.Certificate {
+	now := time.Now()
+	template := x509.Certificate{
+		Subject:     pkix.Name{CommonName: "gonec"},
+		NotBefore:   now,
+		NotAfter:    now.Add(lifetime),
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:        true,
+
+		BasicConstraintsValid: true,
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, h)
+		}
+	}
+	return renewTemplate(template, now)
+}
+
+type CertificateManager struct {
+	certPath string
+	keyPath  string
+	template x509.Certificate
+	logger   *zerolog.Logger
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+func NewCertificateManager(
+	certPath, keyPath string,
+	template x509.Certificate,
+	logger *zerolog.Logger,
+) (*CertificateManager, error) {
+	if logger == nil {
+		logger = zerolog.DefaultContextLogger
+	}
+	m := &CertificateManager{
+		certPath: certPath,
+		keyPath:  keyPath,
+		template: template,
+		logger:   logger,
+	}
+	if err := m.reload(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *CertificateManager) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.cert, nil
+}
+
+// Run periodically renews the certificate from the same key before it
+// expires, until ctx is done.
+func (m *CertificateManager) Run(ctx context.Context) {
+	ticker := time.NewTicker(certCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.mu.RLock()
+			cert := m.cert
+			m.mu.RUnlock()
+
+			if certificateUsable(cert.Leaf, cert.PrivateKey.(ed25519.PrivateKey), m.template, time.Now()) {
+				continue
+			}
+			if err := m.reload(); err != nil {
+				m.logger.Error().
+					Err(err).
+					Str("file", m.certPath).
+					Msg("failed to renew certificate")
+				continue
+			}
+			m.logger.Info().
+				Str("file", m.certPath).
+				Msg("reloaded certificate")
+		}
+	}
+}
+
+func (m *CertificateManager) reload() error {
+	certPEM, keyPEM, err := EnsureX509KeyPair(m.certPath, m.keyPath, m.template, m.logger)
+	if err != nil {
+		return fmt.Errorf("ensure key pair: %w", err)
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("load key pair: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cert = &cert
+	return nil
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+)
+
+type Attachment struct {
+	shared.Attachment
+	Owner     uuid.UUID
+	CreatedAt time.Time
+}
+
+// AttachmentRecipient is a user sent an attachment, who is expected to
+// fetch it.
+type AttachmentRecipient struct {
+	AttachmentID uuid.UUID
+	UserID       uuid.UUID
+	Fetched      bool
+}
+
+type AttachmentRepository interface {
+	Save(ctx context.Context, a Attachment) error
+	GetByID(ctx context.Context, id uuid.UUID) (Attachment, error)
+	// Usage is the total size of the attachments of owner.
+	Usage(ctx context.Context, owner uuid.UUID) (int64, error)
+	CountByHash(ctx context.Context, hash []byte) (int, error)
+	// ListUnsent lists the attachments created before t without recipients.
+	ListUnsent(ctx context.Context, t time.Time) ([]Attachment, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	AddRecipient(ctx context.Context, r AttachmentRecipient) error
+	GetRecipient(ctx context.Context, id, user uuid.UUID) (AttachmentRecipient, error)
+	MarkFetched(ctx context.Context, id, user uuid.UUID) error
+	CountUnfetched(ctx context.Context, id uuid.UUID) (int, error)
+}
+
+// BlobStore keeps blobs by the SHA-256 of their contents.
+type BlobStore interface {
+	// Put stages the contents of r, for the blob to be stored once it is
+	// committed. It fails with ErrResourceExhausted, staging nothing, if r
+	// holds more than limit bytes.
+	Put(ctx context.Context, r io.Reader, limit int64) (StagedBlob, error)
+	Open(hash []byte) (io.ReadCloser, error)
+	Delete(hash []byte) error
+}
+
+// StagedBlob is an uploaded blob not yet stored under its hash.
+type StagedBlob interface {
+	Hash() []byte
+	Size() int64
+	// Commit stores the blob under its hash.
+	Commit() error
+	// Discard drops the blob unless it was committed.
+	Discard() error
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BlockPolicy controls what happens to messages sent to a user who blocked
+// the sender.
+type BlockPolicy int
+
+const (
+	// BlockDrop accepts the message as if delivered and discards it, so the
+	// sender cannot tell they are blocked.
+	BlockDrop BlockPolicy = iota
+	// BlockReject fails the send with ErrPermissionDenied.
+	BlockReject
+)
+
+type BlockRecord struct {
+	Blocker   uuid.UUID
+	Blocked   uuid.UUID
+	CreatedAt time.Time
+}
+
+type BlockRepository interface {
+	// Add does nothing if blocker already blocked the user.
+	Add(ctx context.Context, b BlockRecord) error
+	Remove(ctx context.Context, blocker, blocked uuid.UUID) error
+	List(ctx context.Context, blocker uuid.UUID) ([]BlockRecord, error)
+	Exists(ctx context.Context, blocker, blocked uuid.UUID) (bool, error)
+}
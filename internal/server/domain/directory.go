@@ -0,0 +1,13 @@
+package domain
+
+// DirectoryPolicy controls who may look users up by name or ID.
+type DirectoryPolicy int
+
+const (
+	// DirectoryMembers allows lookups to users with a valid session.
+	DirectoryMembers DirectoryPolicy = iota
+	// DirectoryPublic allows lookups without a session.
+	DirectoryPublic
+	// DirectoryClosed denies lookups, and sending by name with them.
+	DirectoryClosed
+)
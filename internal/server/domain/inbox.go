@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+)
+
+// OverflowPolicy controls what happens to an event for a user whose inbox
+// is full.
+type OverflowPolicy int
+
+const (
+	// OverflowReject fails the publish with ErrResourceExhausted.
+	OverflowReject OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest queued event to make room.
+	OverflowDropOldest
+	// OverflowSpill keeps the event in an InboxRepository until there is
+	// room, so it survives a restart as well.
+	OverflowSpill
+)
+
+// InboxRepository holds the events spilled from full inboxes, in the order
+// they were pushed.
+type InboxRepository interface {
+	Push(ctx context.Context, user uuid.UUID, ev shared.Event) error
+	// Pop removes and returns up to n of the oldest events of user.
+	Pop(ctx context.Context, user uuid.UUID, n int) ([]shared.Event, error)
+	Count(ctx context.Context, user uuid.UUID) (int, error)
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MessageRecord is a delivered message as kept by the server. A deleted
+// message keeps its record with the content cleared.
+type MessageRecord struct {
+	ID        uuid.UUID
+	Sender    uuid.UUID
+	Recipient uuid.UUID
+	Content   string
+	SentAt    time.Time
+	EditedAt  time.Time
+	DeletedAt time.Time
+
+	ReplyTo  uuid.UUID
+	ThreadID uuid.UUID
+}
+
+// ThreadQuery selects the replies in the thread with root Root, oldest
+// first, starting after the reply After unless it is zero.
+type ThreadQuery struct {
+	Root  uuid.UUID
+	After uuid.UUID
+	Limit int
+}
+
+// MessageList is a page of messages, followed by more starting after
+// Cursor unless it is zero.
+type MessageList struct {
+	Messages []MessageRecord
+	Cursor   uuid.UUID
+}
+
+// MessageRevision is the content a message had until it was edited or
+// deleted at ReplacedAt, kept for audit.
+type MessageRevision struct {
+	MessageID  uuid.UUID
+	Content    string
+	ReplacedAt time.Time
+}
+
+type MessageRepository interface {
+	Save(ctx context.Context, m MessageRecord) error
+	GetByID(ctx context.Context, id uuid.UUID) (MessageRecord, error)
+	Update(ctx context.Context, m MessageRecord) error
+	ListThread(ctx context.Context, q ThreadQuery) (MessageList, error)
+	AddRevision(ctx context.Context, r MessageRevision) error
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+)
+
+// BroadcastFilter selects the recipients of a broadcast: the users with the
+// given IDs, or every user if there are none, narrowed down to the given
+// states if any.
+type BroadcastFilter struct {
+	UserIDs []uuid.UUID
+	States  []UserState
+}
+
+// NoticeRepository holds notices until each of their recipients has been
+// sent them.
+type NoticeRepository interface {
+	Save(ctx context.Context, n shared.Notice, recipients []uuid.UUID) error
+	ListPending(ctx context.Context, user uuid.UUID) ([]shared.Notice, error)
+	// Claim removes notice id from those pending for user, and reports
+	// whether it was.
+	Claim(ctx context.Context, user, id uuid.UUID) (bool, error)
+}
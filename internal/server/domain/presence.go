@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PresenceRecord struct {
+	UserID   uuid.UUID
+	LastSeen time.Time
+	Hidden   bool
+}
+
+type PresenceRepository interface {
+	Get(ctx context.Context, id uuid.UUID) (PresenceRecord, error)
+	UpdateLastSeen(ctx context.Context, id uuid.UUID, t time.Time) error
+	UpdateHidden(ctx context.Context, id uuid.UUID, hidden bool) error
+}
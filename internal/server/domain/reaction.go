@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+)
+
+type ReactionRecord struct {
+	MessageID uuid.UUID
+	UserID    uuid.UUID
+	Emoji     string
+	CreatedAt time.Time
+}
+
+type ReactionRepository interface {
+	// Add does nothing if the user already reacted with the same emoji.
+	Add(ctx context.Context, r ReactionRecord) error
+	Remove(ctx context.Context, message, user uuid.UUID, emoji string) error
+	// Counts returns the reaction counts of each of messages that has any,
+	// in the order the reactions were first made.
+	Counts(ctx context.Context, messages []uuid.UUID) (map[uuid.UUID][]shared.ReactionCount, error)
+}
@@ -8,9 +8,14 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"math/big"
 	"os"
+	"slices"
+	"time"
 
 	"github.com/rs/zerolog"
+
+	shared "github.com/charadev96/gonec/internal/shared/domain"
 )
 
 const (
@@ -76,6 +81,23 @@ func EnsureX509KeyPair(
 		if err != nil {
 			return nil, nil, err
 		}
+		cert, err := parseCertificatePEM(certPEM)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse certificate: %w", err)
+		}
+		if !certificateUsable(cert, key, template, time.Now()) {
+			logger.Warn().
+				Str("file", certPath).
+				Time("not_after", cert.NotAfter).
+				Msg("certificate expiring or does not match key and hosts")
+			certPEM, err = generateCertificateFile(certPath, key, renewTemplate(template, time.Now()))
+			if err != nil {
+				return nil, nil, fmt.Errorf("renew certificate: %w", err)
+			}
+			logger.Info().
+				Str("file", certPath).
+				Msg("renewed certificate")
+		}
 	}
 
 	logger.Info().
@@ -125,6 +147,9 @@ func loadKeyFile(keyPath string) (ed25519.PrivateKey, []byte, error) {
 	}
 
 	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, nil, shared.NewError(shared.ErrInvalid, "bad key format, must be PEM")
+	}
 	keyAny, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
 	if err != nil {
 		return nil, nil, fmt.Errorf("parse key: %w", err)
@@ -141,7 +166,7 @@ func generateCertificateFile(
 	certPath string, key ed25519.PrivateKey,
 	template x509.Certificate,
 ) ([]byte, error) {
-	certFile, err := os.OpenFile(certPath, os.O_WRONLY|os.O_CREATE, permCert)
+	certFile, err := os.OpenFile(certPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, permCert)
 	if err != nil {
 		return nil, err
 	}
@@ -168,3 +193,45 @@ func generateCertificateFile(
 
 	return certBuf.Bytes(), nil
 }
+
+func parseCertificatePEM(certPEM []byte) (*x509.Certificate, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil || certBlock.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("bad certificate format, must be PEM")
+	}
+	return x509.ParseCertificate(certBlock.Bytes)
+}
+
+// certificateUsable reports whether cert belongs to key, covers the hosts
+// of template and has more than a third of its lifetime left.
+func certificateUsable(cert *x509.Certificate, key ed25519.PrivateKey, template x509.Certificate, now time.Time) bool {
+	pub, ok := cert.PublicKey.(ed25519.PublicKey)
+	if !ok || !pub.Equal(key.Public()) {
+		return false
+	}
+	for _, name := range template.DNSNames {
+		if !slices.Contains(cert.DNSNames, name) {
+			return false
+		}
+	}
+	for _, ip := range template.IPAddresses {
+		if !slices.ContainsFunc(cert.IPAddresses, ip.Equal) {
+			return false
+		}
+	}
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	return now.Before(cert.NotAfter.Add(-lifetime / 3))
+}
+
+// renewTemplate moves the validity window of template to start at now,
+// keeping its length, and assigns a fresh serial number.
+func renewTemplate(template x509.Certificate, now time.Time) x509.Certificate {
+	lifetime := template.NotAfter.Sub(template.NotBefore)
+	template.NotBefore = now
+	template.NotAfter = now.Add(lifetime)
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err == nil {
+		template.SerialNumber = serial
+	}
+	return template
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+)
+
+func ensure(t *testing.T, certPath, keyPath string, hosts ...string) []byte {
+	t.Helper()
+	l := zerolog.Nop()
+	certPEM, _, err := EnsureX509KeyPair(certPath, keyPath, CertificateTemplate(hosts, 3*time.Hour), &l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPEM
+}
+
+func TestEnsureX509KeyPairRenewal(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	first := ensure(t, certPath, keyPath, "127.0.0.1")
+	if again := ensure(t, certPath, keyPath, "127.0.0.1"); !bytes.Equal(again, first) {
+		t.Fatal("renewed a certificate with most of its lifetime left")
+	}
+
+	withHost := ensure(t, certPath, keyPath, "127.0.0.1", "gonec.example")
+	if bytes.Equal(withHost, first) {
+		t.Fatal("kept a certificate missing a host")
+	}
+	cert, err := parseCertificatePEM(withHost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cert.DNSNames) != 1 || cert.DNSNames[0] != "gonec.example" {
+		t.Fatalf("renewed certificate names %v", cert.DNSNames)
+	}
+
+	// Backdate the certificate so that less than a third of it is left.
+	key, _, err := loadKeyFile(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := CertificateTemplate([]string{"127.0.0.1"}, 3*time.Hour)
+	old.NotBefore = old.NotBefore.Add(-150 * time.Minute)
+	old.NotAfter = old.NotAfter.Add(-150 * time.Minute)
+	expiring, err := generateCertificateFile(certPath, key, old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	renewed := ensure(t, certPath, keyPath, "127.0.0.1")
+	if bytes.Equal(renewed, expiring) {
+		t.Fatal("kept an expiring certificate")
+	}
+	cert, err = parseCertificatePEM(renewed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !certificateUsable(cert, key, CertificateTemplate([]string{"127.0.0.1"}, 3*time.Hour), time.Now()) {
+		t.Fatalf("renewed certificate is not usable: valid %v to %v", cert.NotBefore, cert.NotAfter)
+	}
+	if pub := cert.PublicKey.(ed25519.PublicKey); !pub.Equal(key.Public()) {
+		t.Fatal("renewed certificate has another key")
+	}
+}
+
+func TestLoadKeyFileRejectsNonPEM(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, []byte("not a key"), permKey); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := loadKeyFile(path); !errors.Is(err, shared.ErrInvalid) {
+		t.Fatalf("got %v, want ErrInvalid", err)
+	}
+}
+
+func TestCreateKeyRollover(t *testing.T) {
+	dir := t.TempDir()
+	oldPath, path := filepath.Join(dir, "old.pem"), filepath.Join(dir, "rollover.pem")
+	if _, _, err := generateKeyFile(oldPath); err != nil {
+		t.Fatal(err)
+	}
+	newKey, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+
+	invalid := []struct {
+		name       string
+		key        ed25519.PublicKey
+		start, end time.Time
+	}{
+		{"reversed", newKey, now.Add(time.Hour), now},
+		{"past", newKey, now.Add(-2 * time.Hour), now.Add(-time.Hour)},
+		{"short key", newKey[:8], now, now.Add(time.Hour)},
+	}
+	for _, tt := range invalid {
+		if _, err := CreateKeyRollover(path, oldPath, tt.key, tt.start, tt.end); !errors.Is(err, shared.ErrInvalid) {
+			t.Errorf("%s: got %v, want ErrInvalid", tt.name, err)
+		}
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("rejected rollovers left a file behind: %v", err)
+	}
+
+	r, err := CreateKeyRollover(path, oldPath, newKey, now.Add(-time.Minute), now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadKeyRollover(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(loaded.NewKey, newKey) || !bytes.Equal(loaded.Signature, r.Signature) {
+		t.Fatal("loaded rollover differs from the created one")
+	}
+}
@@ -0,0 +1,44 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	adminpb "github.com/charadev96/gonec/gen/admin"
+	server "github.com/charadev96/gonec/internal/server/domain"
+	"github.com/charadev96/gonec/internal/server/service"
+	"github.com/charadev96/gonec/internal/shared/handler"
+	pb "github.com/charadev96/gonec/internal/shared/pb"
+)
+
+type ChatHandler struct {
+	adminpb.UnimplementedChatServiceServer
+	service *service.ChatService
+}
+
+func NewChatHandler(s *service.ChatService) *ChatHandler {
+	return &ChatHandler{service: s}
+}
+
+func (h *ChatHandler) Broadcast(ctx context.Context, req *adminpb.BroadcastRequest) (*adminpb.BroadcastReply, error) {
+	f := server.BroadcastFilter{
+		UserIDs: make([]uuid.UUID, 0, len(req.UserIds)),
+		States:  pb.UserStatesFromPB(req.States),
+	}
+	for _, s := range req.UserIds {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, handler.ErrArg(err)
+		}
+		f.UserIDs = append(f.UserIDs, id)
+	}
+	n, count, err := h.service.Broadcast(ctx, req.Content, f)
+	if err != nil {
+		return nil, err
+	}
+	return &adminpb.BroadcastReply{
+		Notice:     pb.NoticeToPB(n),
+		Recipients: uint32(count),
+	}, nil
+}
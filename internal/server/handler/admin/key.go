@@ -0,0 +1,37 @@
+package admin
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"time"
+
+	adminpb "github.com/charadev96/gonec/gen/admin"
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+	"github.com/charadev96/gonec/internal/shared/handler"
+	pb "github.com/charadev96/gonec/internal/shared/pb"
+)
+
+// KeyRolloverFunc signs newKey with the gateway key and stores the
+// statement for the gateway to serve.
+type KeyRolloverFunc func(newKey ed25519.PublicKey, notBefore, notAfter time.Time) (shared.KeyRollover, error)
+
+type KeyHandler struct {
+	adminpb.UnimplementedKeyServiceServer
+	rollover KeyRolloverFunc
+}
+
+func NewKeyHandler(f KeyRolloverFunc) *KeyHandler {
+	return &KeyHandler{rollover: f}
+}
+
+func (h *KeyHandler) CreateKeyRollover(ctx context.Context, req *adminpb.CreateKeyRolloverRequest) (*adminpb.CreateKeyRolloverReply, error) {
+	if req.NotBefore == nil || req.NotAfter == nil {
+		return nil, handler.ErrArg(errors.New("missing time period"))
+	}
+	r, err := h.rollover(req.NewPublicKey, req.NotBefore.AsTime(), req.NotAfter.AsTime())
+	if err != nil {
+		return nil, err
+	}
+	return &adminpb.CreateKeyRolloverReply{Rollover: pb.KeyRolloverToPB(r)}, nil
+}
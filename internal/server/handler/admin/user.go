@@ -12,8 +12,6 @@ import (
 	pb "github.com/charadev96/gonec/internal/shared/pb"
 )
 
-// TODO: Sanitize errors
-
 type UserHandler struct {
 	adminpb.UnimplementedUserServiceServer
 	service *service.UserService
@@ -26,7 +24,7 @@ func NewUserHandler(s *service.UserService) *UserHandler {
 func (h *UserHandler) CreateUser(ctx context.Context, req *adminpb.CreateUserRequest) (*adminpb.CreateUserReply, error) {
 	id, err := h.service.Users().Create(ctx)
 	if err != nil {
-		return nil, handler.ErrInternal(err)
+		return nil, err
 	}
 	return &adminpb.CreateUserReply{UserId: id.String()}, nil
 }
@@ -42,7 +40,7 @@ func (h *UserHandler) CreateInvite(ctx context.Context, req *adminpb.CreateInvit
 	}
 	inv, err := h.service.CreateInvite(ctx, id, opts)
 	if err != nil {
-		return nil, handler.ErrInternal(err)
+		return nil, err
 	}
 	return &adminpb.CreateInviteReply{Invite: pb.InviteCredentialToPB(inv)}, nil
 }
@@ -54,7 +52,7 @@ func (h *UserHandler) ExportInvite(ctx context.Context, req *adminpb.ExportInvit
 	}
 	tck, err := h.service.ExportInvite(ctx, id)
 	if err != nil {
-		return nil, handler.ErrInternal(err)
+		return nil, err
 	}
 	return &adminpb.ExportInviteReply{Ticket: pb.InviteTicketToPB(tck)}, nil
 }
@@ -66,7 +64,7 @@ func (h *UserHandler) GetUserByID(ctx context.Context, req *adminpb.GetByIDReque
 	}
 	user, err := h.service.Users().GetByID(ctx, id)
 	if err != nil {
-		return nil, handler.ErrInternal(err)
+		return nil, err
 	}
 	return &adminpb.GetUserReply{User: pb.UserToPB(user)}, nil
 }
@@ -74,7 +72,7 @@ func (h *UserHandler) GetUserByID(ctx context.Context, req *adminpb.GetByIDReque
 func (h *UserHandler) GetUserByName(ctx context.Context, req *adminpb.GetByNameRequest) (*adminpb.GetUserReply, error) {
 	user, err := h.service.Users().GetByName(ctx, req.Name)
 	if err != nil {
-		return nil, handler.ErrInternal(err)
+		return nil, err
 	}
 	return &adminpb.GetUserReply{User: pb.UserToPB(user)}, nil
 }
@@ -86,7 +84,7 @@ func (h *UserHandler) GetInviteByUserID(ctx context.Context, req *adminpb.GetByI
 	}
 	invite, err := h.service.Invites().GetByUserID(ctx, id)
 	if err != nil {
-		return nil, handler.ErrInternal(err)
+		return nil, err
 	}
 	return &adminpb.GetInviteReply{Invite: pb.InviteCredentialToPB(invite)}, nil
 }
@@ -107,7 +105,7 @@ func (h *UserHandler) ListUsers(ctx context.Context, req *adminpb.ListUsersReque
 	}
 	list, err := h.service.Users().List(ctx, query)
 	if err != nil {
-		return nil, handler.ErrInternal(err)
+		return nil, err
 	}
 
 	users := make([]*adminpb.User, len(list.Users))
@@ -129,7 +127,7 @@ func (h *UserHandler) DeleteUser(ctx context.Context, req *adminpb.DeleteRequest
 	}
 	err = h.service.DeleteUser(ctx, id)
 	if err != nil {
-		return nil, handler.ErrInternal(err)
+		return nil, err
 	}
 	return &adminpb.DeleteReply{}, nil
 }
@@ -141,7 +139,7 @@ func (h *UserHandler) DeleteInvite(ctx context.Context, req *adminpb.DeleteReque
 	}
 	err = h.service.Invites().Delete(ctx, id)
 	if err != nil {
-		return nil, handler.ErrInternal(err)
+		return nil, err
 	}
 	return &adminpb.DeleteReply{}, nil
 }
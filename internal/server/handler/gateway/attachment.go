@@ -0,0 +1,154 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+
+	gatewaypb "github.com/charadev96/gonec/gen/gateway"
+	"github.com/charadev96/gonec/internal/server/service"
+	"github.com/charadev96/gonec/internal/shared/handler"
+	pb "github.com/charadev96/gonec/internal/shared/pb"
+)
+
+const attachmentChunkSize = 64 << 10
+
+type AttachmentHandler struct {
+	gatewaypb.UnimplementedAttachmentServiceServer
+
+	ctx     context.Context
+	service *service.AttachmentService
+}
+
+func NewAttachmentHandler(ctx context.Context, s *service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{
+		ctx:     ctx,
+		service: s,
+	}
+}
+
+// Upload takes an open request followed by the chunks of the attachment.
+func (h *AttachmentHandler) Upload(stream grpc.ClientStreamingServer[gatewaypb.UploadRequest, gatewaypb.UploadReply]) error {
+	if context.Cause(h.ctx) != nil {
+		return handler.ErrShutdown
+	}
+
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	open := req.GetOpen()
+	if open == nil {
+		return handler.ErrArg(errors.New("upload must start with open"))
+	}
+	auth, err := pb.SessionFromPB(open.Auth)
+	if err != nil {
+		return handler.ErrArg(err)
+	}
+
+	ctxUp, cancel := mergeCtx(h.ctx, stream.Context())
+	defer cancel()
+	a, err := h.service.Upload(ctxUp, auth, open.MimeType, &uploadReader{stream: stream})
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(&gatewaypb.UploadReply{Attachment: pb.AttachmentToPB(a)})
+}
+
+// Download sends the attachment metadata followed by its chunks.
+func (h *AttachmentHandler) Download(req *gatewaypb.DownloadRequest, stream grpc.ServerStreamingServer[gatewaypb.DownloadReply]) error {
+	if context.Cause(h.ctx) != nil {
+		return handler.ErrShutdown
+	}
+
+	auth, err := pb.SessionFromPB(req.Auth)
+	if err != nil {
+		return handler.ErrArg(err)
+	}
+	id, err := uuid.Parse(req.AttachmentId)
+	if err != nil {
+		return handler.ErrArg(err)
+	}
+
+	a, r, err := h.service.Open(stream.Context(), auth, id)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	err = stream.Send(&gatewaypb.DownloadReply{
+		Payload: &gatewaypb.DownloadReply_Attachment{Attachment: pb.AttachmentToPB(a)},
+	})
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, attachmentChunkSize)
+	for {
+		if context.Cause(h.ctx) != nil {
+			return handler.ErrShutdown
+		}
+		n, err := r.Read(buf)
+		if n > 0 {
+			err := stream.Send(&gatewaypb.DownloadReply{
+				Payload: &gatewaypb.DownloadReply_Chunk{Chunk: buf[:n]},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AckDownload confirms that a download was received in full. The
+// attachment is kept until then, so that a download cut short can be
+// retried.
+func (h *AttachmentHandler) AckDownload(ctx context.Context, req *gatewaypb.AckDownloadRequest) (*gatewaypb.AckDownloadReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	auth, err := pb.SessionFromPB(req.Auth)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	id, err := uuid.Parse(req.AttachmentId)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	if err := h.service.AckDownload(ctx, auth, id); err != nil {
+		return nil, err
+	}
+	return &gatewaypb.AckDownloadReply{}, nil
+}
+
+type uploadReader struct {
+	stream grpc.ClientStreamingServer[gatewaypb.UploadRequest, gatewaypb.UploadReply]
+	buf    []byte
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if req.GetOpen() != nil {
+			return 0, handler.ErrArg(errors.New("upload is already open"))
+		}
+		r.buf = req.GetChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
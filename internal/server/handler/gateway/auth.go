@@ -7,13 +7,10 @@ import (
 
 	gatewaypb "github.com/charadev96/gonec/gen/gateway"
 	"github.com/charadev96/gonec/internal/server/service"
-	shared "github.com/charadev96/gonec/internal/shared/domain"
 	"github.com/charadev96/gonec/internal/shared/handler"
 	pb "github.com/charadev96/gonec/internal/shared/pb"
 )
 
-// TODO: Sanitize errors
-
 type AuthHandler struct {
 	gatewaypb.UnimplementedAuthServiceServer
 	service *service.UserService
@@ -29,7 +26,7 @@ func (h *AuthHandler) Register(ctx context.Context, req *gatewaypb.RegisterReque
 		return nil, handler.ErrArg(err)
 	}
 	if err := h.service.RegisterUser(ctx, id, req.Token, req.PublicKey); err != nil {
-		return nil, handler.ErrInternal(err)
+		return nil, err
 	}
 	return &gatewaypb.RegisterReply{}, nil
 }
@@ -41,7 +38,7 @@ func (h *AuthHandler) InitiateLogin(ctx context.Context, req *gatewaypb.Initiate
 	}
 	nonce, err := h.service.CreateLoginNonce(ctx, id)
 	if err != nil {
-		return nil, handler.ErrInternal(err)
+		return nil, err
 	}
 	return &gatewaypb.InitiateLoginReply{Nonce: nonce}, nil
 }
@@ -53,7 +50,7 @@ func (h *AuthHandler) CompleteLogin(ctx context.Context, req *gatewaypb.Complete
 	}
 	sess, err := h.service.LoginUser(ctx, id, req.Signature)
 	if err != nil {
-		return nil, handler.ErrInternal(err)
+		return nil, err
 	}
 	return &gatewaypb.CompleteLoginReply{
 		Auth: pb.SessionToPB(sess),
@@ -61,17 +58,20 @@ func (h *AuthHandler) CompleteLogin(ctx context.Context, req *gatewaypb.Complete
 }
 
 func (h *AuthHandler) Logout(ctx context.Context, req *gatewaypb.LogoutRequest) (*gatewaypb.LogoutReply, error) {
-	ids, err := handler.ParseUUIDs(req.Auth.Id, req.Auth.UserId)
+	sess, err := pb.SessionFromPB(req.Auth)
 	if err != nil {
 		return nil, handler.ErrArg(err)
 	}
-	sess := shared.Session{
-		ID:     ids[0],
-		UserID: ids[1],
-		Token:  req.Auth.Token,
-	}
 	if err := h.service.LogoutUser(ctx, sess); err != nil {
-		return nil, handler.ErrInternal(err)
+		return nil, err
 	}
 	return &gatewaypb.LogoutReply{}, nil
 }
+
+func (h *AuthHandler) GetKeyRollover(ctx context.Context, req *gatewaypb.GetKeyRolloverRequest) (*gatewaypb.GetKeyRolloverReply, error) {
+	r, err := h.service.KeyRollover()
+	if err != nil {
+		return nil, err
+	}
+	return &gatewaypb.GetKeyRolloverReply{Rollover: pb.KeyRolloverToPB(r)}, nil
+}
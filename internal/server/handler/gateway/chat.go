@@ -2,18 +2,26 @@ package gateway
 
 import (
 	"context"
+	"errors"
+	"io"
 
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 
 	gatewaypb "github.com/charadev96/gonec/gen/gateway"
 	sharedpb "github.com/charadev96/gonec/gen/shared"
 	"github.com/charadev96/gonec/internal/server/service"
 	shared "github.com/charadev96/gonec/internal/shared/domain"
 	"github.com/charadev96/gonec/internal/shared/handler"
+	"github.com/charadev96/gonec/internal/shared/log"
 	pb "github.com/charadev96/gonec/internal/shared/pb"
 )
 
-// TODO: Sanitize errors
+// errStreamClosed ends a stream whose events stopped without an error,
+// for the client to open it again.
+var errStreamClosed = handler.NewStatus(codes.Unavailable, "STREAM_CLOSED", "event stream closed").Err()
 
 type ChatHandler struct {
 	gatewaypb.UnimplementedChatServiceServer
@@ -31,71 +39,498 @@ func NewChatHandler(ctx context.Context, s *service.ChatService) *ChatHandler {
 
 func (h *ChatHandler) Send(ctx context.Context, req *gatewaypb.SendRequest) (*gatewaypb.SendReply, error) {
 	if context.Cause(h.ctx) != nil {
-		return nil, handler.ErrInternal(h.ctx.Err())
+		return nil, handler.ErrShutdown
 	}
 
-	ids, err := handler.ParseUUIDs(req.Auth.Id, req.Auth.UserId, req.Recipient)
+	auth, err := pb.SessionFromPB(req.Auth)
 	if err != nil {
 		return nil, handler.ErrArg(err)
 	}
-	auth := shared.Session{
-		ID:     ids[0],
-		UserID: ids[1],
-		Token:  req.Auth.Token,
+	attachments, err := handler.ParseUUIDs(req.AttachmentIds...)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	replyTo, err := handler.ParseOptionalUUID(req.ReplyTo)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	msg, err := h.service.Send(ctx, auth, req.Recipient, req.Content, attachments, replyTo)
+	if err != nil {
+		return nil, err
+	}
+	reply := &gatewaypb.SendReply{
+		RecipientId: msg.Recipient.String(),
+		MessageId:   msg.ID.String(),
+	}
+	if msg.ThreadID != uuid.Nil {
+		reply.ThreadId = msg.ThreadID.String()
+	}
+	return reply, nil
+}
+
+func (h *ChatHandler) EditMessage(ctx context.Context, req *gatewaypb.EditMessageRequest) (*gatewaypb.EditMessageReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	auth, err := pb.SessionFromPB(req.Auth)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	id, err := uuid.Parse(req.MessageId)
+	if err != nil {
+		return nil, handler.ErrArg(err)
 	}
-	if err := h.service.Send(ctx, auth, ids[2], req.Content); err != nil {
-		return nil, handler.ErrInternal(err)
+	edit, err := h.service.EditMessage(ctx, auth, id, req.Content)
+	if err != nil {
+		return nil, err
 	}
-	return &gatewaypb.SendReply{}, nil
+	return &gatewaypb.EditMessageReply{Edit: pb.MessageEditToPB(edit)}, nil
+}
+
+func (h *ChatHandler) DeleteMessage(ctx context.Context, req *gatewaypb.DeleteMessageRequest) (*gatewaypb.DeleteMessageReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	auth, err := pb.SessionFromPB(req.Auth)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	id, err := uuid.Parse(req.MessageId)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	del, err := h.service.DeleteMessage(ctx, auth, id)
+	if err != nil {
+		return nil, err
+	}
+	return &gatewaypb.DeleteMessageReply{Delete: pb.MessageDeleteToPB(del)}, nil
+}
+
+func (h *ChatHandler) React(ctx context.Context, req *gatewaypb.ReactRequest) (*gatewaypb.ReactReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	auth, err := pb.SessionFromPB(req.Auth)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	id, err := uuid.Parse(req.MessageId)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	r, err := h.service.React(ctx, auth, id, req.Emoji, req.Remove)
+	if err != nil {
+		return nil, err
+	}
+	return &gatewaypb.ReactReply{Reaction: pb.ReactionToPB(r)}, nil
+}
+
+func (h *ChatHandler) ListThread(ctx context.Context, req *gatewaypb.ListThreadRequest) (*gatewaypb.ListThreadReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	auth, err := pb.SessionFromPB(req.Auth)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	id, err := uuid.Parse(req.MessageId)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	after, err := handler.ParseOptionalUUID(req.Cursor)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	page, err := h.service.ListThread(ctx, auth, id, after, int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+	reply := &gatewaypb.ListThreadReply{Messages: pb.MessagesToPB(page.Messages)}
+	if page.Cursor != uuid.Nil {
+		reply.Cursor = page.Cursor.String()
+	}
+	return reply, nil
+}
+
+func (h *ChatHandler) LookupUser(ctx context.Context, req *gatewaypb.LookupUserRequest) (*gatewaypb.LookupUserReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	var auth *shared.Session
+	if req.Auth != nil {
+		sess, err := pb.SessionFromPB(req.Auth)
+		if err != nil {
+			return nil, handler.ErrArg(err)
+		}
+		auth = &sess
+	}
+	user, err := h.service.LookupUser(ctx, auth, req.User)
+	if err != nil {
+		return nil, err
+	}
+	return &gatewaypb.LookupUserReply{User: pb.UserIdentityToPB(user)}, nil
 }
 
 func (h *ChatHandler) Listen(req *gatewaypb.ListenRequest, stream grpc.ServerStreamingServer[sharedpb.Message]) error {
 	if context.Cause(h.ctx) != nil {
-		return handler.ErrInternal(h.ctx.Err())
+		return handler.ErrShutdown
 	}
 
-	ids, err := handler.ParseUUIDs(req.Auth.Id, req.Auth.UserId)
+	auth, err := pb.SessionFromPB(req.Auth)
 	if err != nil {
 		return handler.ErrArg(err)
 	}
-	auth := shared.Session{
-		ID:     ids[0],
-		UserID: ids[1],
-		Token:  req.Auth.Token,
-	}
 
-	ctxLn, _ := mergeCtx(h.ctx, stream.Context())
+	ctxLn, cancel := mergeCtx(h.ctx, stream.Context())
+	defer cancel()
 	ln, err := h.service.Listen(ctxLn, auth)
 	if err != nil {
-		return handler.ErrInternal(err)
+		return err
 	}
 
 	for {
 		select {
 		case <-h.ctx.Done():
-			return handler.ErrInternal(h.ctx.Err())
+			return handler.ErrShutdown
 		case <-stream.Context().Done():
-			return handler.ErrInternal(stream.Context().Err())
-		case pck := <-ln:
+			return stream.Context().Err()
+		case pck, ok := <-ln:
+			if !ok {
+				return errStreamClosed
+			}
 			if pck.Err != nil {
-				return handler.ErrInternal(err)
+				return pck.Err
 			}
 			if err := stream.Send(pb.MessageToPB(pck.Msg)); err != nil {
-				return handler.ErrInternal(pck.Err)
+				return err
 			}
 		}
 	}
 }
 
-func mergeCtx(ctx1, ctx2 context.Context) (context.Context, context.CancelFunc) {
-	ctx, cancel := context.WithCancel(context.Background())
+func (h *ChatHandler) Events(req *gatewaypb.EventsRequest, stream grpc.ServerStreamingServer[sharedpb.Event]) error {
+	if context.Cause(h.ctx) != nil {
+		return handler.ErrShutdown
+	}
+
+	auth, err := pb.SessionFromPB(req.Auth)
+	if err != nil {
+		return handler.ErrArg(err)
+	}
+	presence, err := handler.ParseUUIDs(req.PresenceUserIds...)
+	if err != nil {
+		return handler.ErrArg(err)
+	}
+
+	ctxLn, cancel := mergeCtx(h.ctx, stream.Context())
+	defer cancel()
+	ln, err := h.service.Events(ctxLn, auth, presence)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-h.ctx.Done():
+			return handler.ErrShutdown
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case pck, ok := <-ln:
+			if !ok {
+				return errStreamClosed
+			}
+			if pck.Err != nil {
+				return pck.Err
+			}
+			if err := stream.Send(pb.EventToPB(pck.Msg)); err != nil {
+				return err
+			}
+			if err := h.claimNotice(ctxLn, auth, pck.Msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// claimNotice marks ev as sent to the session user if it is a notice, even
+// if the stream ends meanwhile.
+func (h *ChatHandler) claimNotice(ctx context.Context, auth shared.Session, ev shared.Event) error {
+	if ev.Notice == nil {
+		return nil
+	}
+	return h.service.ClaimNotice(context.WithoutCancel(ctx), auth.UserID, ev.Notice.ID)
+}
+
+// Chat carries events and sends on one stream. The first request opens it,
+// each following one is a send answered by an ack with the same ID; sends
+// are handled in order.
+func (h *ChatHandler) Chat(stream grpc.BidiStreamingServer[gatewaypb.ChatRequest, gatewaypb.ChatReply]) error {
+	if context.Cause(h.ctx) != nil {
+		return handler.ErrShutdown
+	}
+
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	open := req.GetOpen()
+	if open == nil {
+		return handler.ErrArg(errors.New("chat stream must start with open"))
+	}
+	auth, err := pb.SessionFromPB(open.Auth)
+	if err != nil {
+		return handler.ErrArg(err)
+	}
+	presence, err := handler.ParseUUIDs(open.PresenceUserIds...)
+	if err != nil {
+		return handler.ErrArg(err)
+	}
+
+	ctxLn, cancel := mergeCtx(h.ctx, stream.Context())
+	defer cancel()
+	chat, err := h.service.Chat(ctxLn, auth, presence)
+	if err != nil {
+		return err
+	}
+
+	// Sends share the request ID of the stream, the ack ID tells them apart.
+	l := log.Ctx(stream.Context(), zerolog.Nop())
+	acks := make(chan *gatewaypb.ChatAck)
+	errs := make(chan error, 1)
 	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				errs <- err
+				return
+			}
+			send := req.GetSend()
+			if send == nil {
+				errs <- handler.ErrArg(errors.New("chat stream is already open"))
+				return
+			}
+			var msg shared.Message
+			attachments, err := handler.ParseUUIDs(send.AttachmentIds...)
+			if err != nil {
+				err = handler.ErrArg(err)
+			}
+			replyTo, perr := handler.ParseOptionalUUID(send.ReplyTo)
+			if err == nil && perr != nil {
+				err = handler.ErrArg(perr)
+			}
+			if err == nil {
+				msg, err = chat.Send(ctxLn, send.Recipient, send.Content, attachments, replyTo)
+			}
+			ack := chatAckToPB(send.Id, msg, err)
+			l.Info().
+				Uint64("ack.id", send.Id).
+				Str("code", codes.Code(ack.Code).String()).
+				Msg("chat send")
+			select {
+			case acks <- ack:
+			case <-ctxLn.Done():
+				return
+			}
+			// The client logs in again on a stream ended this way.
+			if errors.Is(err, shared.ErrUnauthenticated) || errors.Is(err, shared.ErrExpired) {
+				errs <- err
+				return
+			}
+		}
+	}()
+
+	for {
 		select {
-		case <-ctx1.Done():
-		case <-ctx2.Done():
-		case <-ctx.Done():
+		case <-h.ctx.Done():
+			return handler.ErrShutdown
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case err := <-errs:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		case ack := <-acks:
+			if err := stream.Send(&gatewaypb.ChatReply{Payload: &gatewaypb.ChatReply_Ack{Ack: ack}}); err != nil {
+				return err
+			}
+		case pck, ok := <-chat.Events:
+			if !ok {
+				return errStreamClosed
+			}
+			if pck.Err != nil {
+				return pck.Err
+			}
+			if err := stream.Send(&gatewaypb.ChatReply{Payload: &gatewaypb.ChatReply_Event{Event: pb.EventToPB(pck.Msg)}}); err != nil {
+				return err
+			}
+			if err := h.claimNotice(ctxLn, auth, pck.Msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func chatAckToPB(id uint64, msg shared.Message, err error) *gatewaypb.ChatAck {
+	if err != nil {
+		st := handler.Status(err)
+		reason, _ := handler.Reason(st)
+		return &gatewaypb.ChatAck{
+			Id:     id,
+			Code:   uint32(st.Code()),
+			Error:  st.Message(),
+			Reason: reason,
 		}
+	}
+	return &gatewaypb.ChatAck{
+		Id:      id,
+		Message: pb.MessageToPB(msg),
+	}
+}
+
+// mergeCtx returns a context done once either ctx1 or ctx2 is, carrying the
+// values of ctx2.
+func mergeCtx(ctx1, ctx2 context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx2)
+	stop := context.AfterFunc(ctx1, cancel)
+	return ctx, func() {
+		stop()
 		cancel()
-	}()
-	return ctx, cancel
+	}
+}
+
+func (h *ChatHandler) GetPresence(ctx context.Context, req *gatewaypb.GetPresenceRequest) (*gatewaypb.GetPresenceReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	auth, err := pb.SessionFromPB(req.Auth)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	ids, err := handler.ParseUUIDs(req.UserIds...)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	ps, err := h.service.Presence(ctx, auth, ids)
+	if err != nil {
+		return nil, err
+	}
+	return &gatewaypb.GetPresenceReply{Presences: pb.PresencesToPB(ps)}, nil
+}
+
+func (h *ChatHandler) WatchPresence(req *gatewaypb.WatchPresenceRequest, stream grpc.ServerStreamingServer[sharedpb.Presence]) error {
+	if context.Cause(h.ctx) != nil {
+		return handler.ErrShutdown
+	}
+
+	auth, err := pb.SessionFromPB(req.Auth)
+	if err != nil {
+		return handler.ErrArg(err)
+	}
+	ids, err := handler.ParseUUIDs(req.UserIds...)
+	if err != nil {
+		return handler.ErrArg(err)
+	}
+
+	ctxLn, cancel := mergeCtx(h.ctx, stream.Context())
+	defer cancel()
+	ln, err := h.service.WatchPresence(ctxLn, auth, ids)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-h.ctx.Done():
+			return handler.ErrShutdown
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case pck, ok := <-ln:
+			if !ok {
+				return errStreamClosed
+			}
+			if pck.Err != nil {
+				return pck.Err
+			}
+			if err := stream.Send(pb.PresenceToPB(pck.Msg)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (h *ChatHandler) SetPresenceHidden(ctx context.Context, req *gatewaypb.SetPresenceHiddenRequest) (*gatewaypb.SetPresenceHiddenReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	auth, err := pb.SessionFromPB(req.Auth)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	if err := h.service.SetPresenceHidden(ctx, auth, req.Hidden); err != nil {
+		return nil, err
+	}
+	return &gatewaypb.SetPresenceHiddenReply{}, nil
+}
+
+func (h *ChatHandler) BlockUser(ctx context.Context, req *gatewaypb.BlockUserRequest) (*gatewaypb.BlockUserReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	auth, err := pb.SessionFromPB(req.Auth)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	b, err := h.service.BlockUser(ctx, auth, req.User)
+	if err != nil {
+		return nil, err
+	}
+	return &gatewaypb.BlockUserReply{Block: pb.BlockToPB(b)}, nil
+}
+
+func (h *ChatHandler) UnblockUser(ctx context.Context, req *gatewaypb.UnblockUserRequest) (*gatewaypb.UnblockUserReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	auth, err := pb.SessionFromPB(req.Auth)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	id, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	if err := h.service.UnblockUser(ctx, auth, id); err != nil {
+		return nil, err
+	}
+	return &gatewaypb.UnblockUserReply{}, nil
+}
+
+func (h *ChatHandler) ListBlocks(ctx context.Context, req *gatewaypb.ListBlocksRequest) (*gatewaypb.ListBlocksReply, error) {
+	if context.Cause(h.ctx) != nil {
+		return nil, handler.ErrShutdown
+	}
+
+	auth, err := pb.SessionFromPB(req.Auth)
+	if err != nil {
+		return nil, handler.ErrArg(err)
+	}
+	bs, err := h.service.ListBlocks(ctx, auth)
+	if err != nil {
+		return nil, err
+	}
+	reply := &gatewaypb.ListBlocksReply{Blocks: make([]*sharedpb.Block, 0, len(bs))}
+	for _, b := range bs {
+		reply.Blocks = append(reply.Blocks, pb.BlockToPB(b))
+	}
+	return reply, nil
 }
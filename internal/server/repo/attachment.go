@@ -0,0 +1,234 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	server "github.com/charadev96/gonec/internal/server/domain"
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+	"github.com/charadev96/gonec/internal/shared/infra"
+)
+
+type BunAttachmentRepository struct {
+	db *bun.DB
+}
+
+func NewBunAttachmentRepository(ctx context.Context, db *bun.DB) (*BunAttachmentRepository, error) {
+	r := &BunAttachmentRepository{
+		db: db,
+	}
+	tx := infra.ExtractTx(ctx, r.db)
+	for _, model := range []any{(*attachment)(nil), (*attachmentRecipient)(nil)} {
+		_, err := tx.NewCreateTable().
+			Model(model).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return r, err
+		}
+	}
+	_, err := tx.NewCreateIndex().
+		Model((*attachment)(nil)).
+		Index("attachments_hash_idx").
+		Column("hash").
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+func (r *BunAttachmentRepository) Save(ctx context.Context, a server.Attachment) error {
+	tx := infra.ExtractTx(ctx, r.db)
+	_, err := tx.NewInsert().
+		Model(attachmentToDB(a)).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *BunAttachmentRepository) GetByID(ctx context.Context, id uuid.UUID) (server.Attachment, error) {
+	tx := infra.ExtractTx(ctx, r.db)
+	a := &attachment{}
+	err := tx.NewSelect().
+		Model(a).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = shared.ErrNotExist
+		}
+		return server.Attachment{}, err
+	}
+	return attachmentFromDB(*a), nil
+}
+
+func (r *BunAttachmentRepository) Usage(ctx context.Context, owner uuid.UUID) (int64, error) {
+	tx := infra.ExtractTx(ctx, r.db)
+	var usage int64
+	err := tx.NewSelect().
+		Model((*attachment)(nil)).
+		ColumnExpr("COALESCE(SUM(size), 0)").
+		Where("owner = ?", owner).
+		Scan(ctx, &usage)
+	if err != nil {
+		return 0, err
+	}
+	return usage, nil
+}
+
+func (r *BunAttachmentRepository) CountByHash(ctx context.Context, hash []byte) (int, error) {
+	tx := infra.ExtractTx(ctx, r.db)
+	return tx.NewSelect().
+		Model((*attachment)(nil)).
+		Where("hash = ?", hash).
+		Count(ctx)
+}
+
+func (r *BunAttachmentRepository) ListUnsent(ctx context.Context, t time.Time) ([]server.Attachment, error) {
+	tx := infra.ExtractTx(ctx, r.db)
+	var as []attachment
+	err := tx.NewSelect().
+		Model(&as).
+		Where("created_at < ?", t).
+		Where("NOT EXISTS (SELECT 1 FROM attachment_recipients AS r WHERE r.attachment_id = a.id)").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]server.Attachment, 0, len(as))
+	for _, a := range as {
+		list = append(list, attachmentFromDB(a))
+	}
+	return list, nil
+}
+
+func (r *BunAttachmentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tx := infra.ExtractTx(ctx, r.db)
+	_, err := tx.NewDelete().
+		Model((*attachmentRecipient)(nil)).
+		Where("attachment_id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.NewDelete().
+		Model(&attachment{ID: id}).
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *BunAttachmentRepository) AddRecipient(ctx context.Context, rcp server.AttachmentRecipient) error {
+	tx := infra.ExtractTx(ctx, r.db)
+	_, err := tx.NewInsert().
+		Model(&attachmentRecipient{
+			AttachmentID: rcp.AttachmentID,
+			UserID:       rcp.UserID,
+			Fetched:      rcp.Fetched,
+		}).
+		Ignore().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *BunAttachmentRepository) GetRecipient(ctx context.Context, id, user uuid.UUID) (server.AttachmentRecipient, error) {
+	tx := infra.ExtractTx(ctx, r.db)
+	rcp := &attachmentRecipient{}
+	err := tx.NewSelect().
+		Model(rcp).
+		Where("attachment_id = ?", id).
+		Where("user_id = ?", user).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = shared.ErrNotExist
+		}
+		return server.AttachmentRecipient{}, err
+	}
+	return server.AttachmentRecipient{
+		AttachmentID: rcp.AttachmentID,
+		UserID:       rcp.UserID,
+		Fetched:      rcp.Fetched,
+	}, nil
+}
+
+func (r *BunAttachmentRepository) MarkFetched(ctx context.Context, id, user uuid.UUID) error {
+	tx := infra.ExtractTx(ctx, r.db)
+	_, err := tx.NewUpdate().
+		Model((*attachmentRecipient)(nil)).
+		Set("fetched = ?", true).
+		Where("attachment_id = ?", id).
+		Where("user_id = ?", user).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *BunAttachmentRepository) CountUnfetched(ctx context.Context, id uuid.UUID) (int, error) {
+	tx := infra.ExtractTx(ctx, r.db)
+	return tx.NewSelect().
+		Model((*attachmentRecipient)(nil)).
+		Where("attachment_id = ?", id).
+		Where("fetched = ?", false).
+		Count(ctx)
+}
+
+type attachment struct {
+	bun.BaseModel `bun:"table:attachments,alias:a"`
+
+	ID        uuid.UUID `bun:",pk"`
+	Owner     uuid.UUID `bun:",notnull"`
+	Hash      []byte    `bun:",notnull"`
+	Size      int64     `bun:",notnull"`
+	MIMEType  string    `bun:"mime_type,notnull"`
+	CreatedAt time.Time `bun:",notnull"`
+}
+
+type attachmentRecipient struct {
+	bun.BaseModel `bun:"table:attachment_recipients,alias:r"`
+
+	AttachmentID uuid.UUID `bun:",pk"`
+	UserID       uuid.UUID `bun:",pk"`
+	Fetched      bool      `bun:",notnull"`
+}
+
+func attachmentFromDB(a attachment) server.Attachment {
+	return server.Attachment{
+		Attachment: shared.Attachment{
+			ID:       a.ID,
+			Size:     a.Size,
+			MIMEType: a.MIMEType,
+			Hash:     a.Hash,
+		},
+		Owner:     a.Owner,
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+func attachmentToDB(a server.Attachment) *attachment {
+	return &attachment{
+		ID:        a.ID,
+		Owner:     a.Owner,
+		Hash:      a.Hash,
+		Size:      a.Size,
+		MIMEType:  a.MIMEType,
+		CreatedAt: a.CreatedAt,
+	}
+}
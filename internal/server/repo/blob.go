@@ -0,0 +1,121 @@
+package repo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	server "github.com/charadev96/gonec/internal/server/domain"
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+)
+
+const (
+	permBlobDir  = 0700
+	permBlobFile = 0600
+)
+
+// FileBlobStore keeps each blob in a file named by the hex SHA-256 of its
+// contents, fanned out into subdirectories by the first byte.
+type FileBlobStore struct {
+	dir string
+}
+
+func NewFileBlobStore(dir string) (*FileBlobStore, error) {
+	if err := os.MkdirAll(dir, permBlobDir); err != nil {
+		return nil, fmt.Errorf("create blob directory: %w", err)
+	}
+	return &FileBlobStore{dir: dir}, nil
+}
+
+// Put writes the contents of r to a temporary file, which Commit renames
+// into place.
+func (s *FileBlobStore) Put(ctx context.Context, r io.Reader, limit int64) (server.StagedBlob, error) {
+	f, err := os.CreateTemp(s.dir, "upload*")
+	if err != nil {
+		return nil, err
+	}
+	b := &stagedFile{store: s, tmp: f.Name()}
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(f, h), io.LimitReader(r, limit+1))
+	if err == nil && size > limit {
+		err = fmt.Errorf("blob larger than %d bytes: %w", limit, shared.ErrResourceExhausted)
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		b.Discard()
+		return nil, err
+	}
+	b.hash = h.Sum(nil)
+	b.size = size
+	return b, nil
+}
+
+func (s *FileBlobStore) Open(hash []byte) (io.ReadCloser, error) {
+	f, err := os.Open(s.path(hash))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("blob %x: %w", hash, shared.ErrNotExist)
+	}
+	return f, err
+}
+
+func (s *FileBlobStore) Delete(hash []byte) error {
+	err := os.Remove(s.path(hash))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+type stagedFile struct {
+	store *FileBlobStore
+	tmp   string
+	hash  []byte
+	size  int64
+}
+
+func (b *stagedFile) Hash() []byte {
+	return b.hash
+}
+
+func (b *stagedFile) Size() int64 {
+	return b.size
+}
+
+func (b *stagedFile) Commit() error {
+	path := b.store.path(b.hash)
+	if err := os.MkdirAll(filepath.Dir(path), permBlobDir); err != nil {
+		return err
+	}
+	if err := os.Chmod(b.tmp, permBlobFile); err != nil {
+		return err
+	}
+	return os.Rename(b.tmp, path)
+}
+
+func (b *stagedFile) Discard() error {
+	err := os.Remove(b.tmp)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *FileBlobStore) path(hash []byte) string {
+	name := hex.EncodeToString(hash)
+	return filepath.Join(s.dir, name[:2], name)
+}
@@ -0,0 +1,98 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	server "github.com/charadev96/gonec/internal/server/domain"
+	"github.com/charadev96/gonec/internal/shared/infra"
+)
+
+type BunBlockRepository struct {
+	db *bun.DB
+}
+
+func NewBunBlockRepository(ctx context.Context, db *bun.DB) (*BunBlockRepository, error) {
+	r := &BunBlockRepository{
+		db: db,
+	}
+	tx := infra.ExtractTx(ctx, r.db)
+	_, err := tx.NewCreateTable().
+		Model((*block)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+func (r *BunBlockRepository) Add(ctx context.Context, b server.BlockRecord) error {
+	tx := infra.ExtractTx(ctx, r.db)
+	_, err := tx.NewInsert().
+		Model(&block{
+			Blocker:   b.Blocker,
+			Blocked:   b.Blocked,
+			CreatedAt: b.CreatedAt,
+		}).
+		Ignore().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *BunBlockRepository) Remove(ctx context.Context, blocker, blocked uuid.UUID) error {
+	tx := infra.ExtractTx(ctx, r.db)
+	_, err := tx.NewDelete().
+		Model(&block{Blocker: blocker, Blocked: blocked}).
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *BunBlockRepository) List(ctx context.Context, blocker uuid.UUID) ([]server.BlockRecord, error) {
+	tx := infra.ExtractTx(ctx, r.db)
+	var bs []block
+	err := tx.NewSelect().
+		Model(&bs).
+		Where("blocker = ?", blocker).
+		Order("created_at").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]server.BlockRecord, 0, len(bs))
+	for _, b := range bs {
+		list = append(list, server.BlockRecord{
+			Blocker:   b.Blocker,
+			Blocked:   b.Blocked,
+			CreatedAt: b.CreatedAt,
+		})
+	}
+	return list, nil
+}
+
+func (r *BunBlockRepository) Exists(ctx context.Context, blocker, blocked uuid.UUID) (bool, error) {
+	tx := infra.ExtractTx(ctx, r.db)
+	return tx.NewSelect().
+		Model((*block)(nil)).
+		Where("blocker = ?", blocker).
+		Where("blocked = ?", blocked).
+		Exists(ctx)
+}
+
+type block struct {
+	bun.BaseModel `bun:"table:blocks"`
+
+	Blocker   uuid.UUID `bun:",pk"`
+	Blocked   uuid.UUID `bun:",pk"`
+	CreatedAt time.Time `bun:",notnull"`
+}
@@ -0,0 +1,95 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+	"github.com/charadev96/gonec/internal/shared/infra"
+)
+
+type BunInboxRepository struct {
+	db *bun.DB
+}
+
+func NewBunInboxRepository(ctx context.Context, db *bun.DB) (*BunInboxRepository, error) {
+	r := &BunInboxRepository{
+		db: db,
+	}
+	tx := infra.ExtractTx(ctx, r.db)
+	_, err := tx.NewCreateTable().
+		Model((*inboxEvent)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+func (r *BunInboxRepository) Push(ctx context.Context, user uuid.UUID, ev shared.Event) error {
+	tx := infra.ExtractTx(ctx, r.db)
+	_, err := tx.NewInsert().
+		Model(&inboxEvent{
+			UserID:    user,
+			Event:     ev,
+			CreatedAt: time.Now(),
+		}).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *BunInboxRepository) Pop(ctx context.Context, user uuid.UUID, n int) ([]shared.Event, error) {
+	tx := infra.ExtractTx(ctx, r.db)
+	var evs []inboxEvent
+	err := tx.NewSelect().
+		Model(&evs).
+		Where("user_id = ?", user).
+		Order("id").
+		Limit(n).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(evs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, 0, len(evs))
+	list := make([]shared.Event, 0, len(evs))
+	for _, ev := range evs {
+		ids = append(ids, ev.ID)
+		list = append(list, ev.Event)
+	}
+	_, err = tx.NewDelete().
+		Model((*inboxEvent)(nil)).
+		Where("id IN (?)", bun.In(ids)).
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *BunInboxRepository) Count(ctx context.Context, user uuid.UUID) (int, error) {
+	tx := infra.ExtractTx(ctx, r.db)
+	return tx.NewSelect().
+		Model((*inboxEvent)(nil)).
+		Where("user_id = ?", user).
+		Count(ctx)
+}
+
+type inboxEvent struct {
+	bun.BaseModel `bun:"table:inbox_events"`
+
+	ID        int64        `bun:",pk,autoincrement"`
+	UserID    uuid.UUID    `bun:",notnull"`
+	Event     shared.Event `bun:",type:json,notnull"`
+	CreatedAt time.Time    `bun:",notnull"`
+}
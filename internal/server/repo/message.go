@@ -0,0 +1,226 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	server "github.com/charadev96/gonec/internal/server/domain"
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+	"github.com/charadev96/gonec/internal/shared/infra"
+)
+
+type BunMessageRepository struct {
+	db *bun.DB
+}
+
+func NewBunMessageRepository(ctx context.Context, db *bun.DB) (*BunMessageRepository, error) {
+	r := &BunMessageRepository{
+		db: db,
+	}
+	tx := infra.ExtractTx(ctx, r.db)
+	for _, model := range []any{(*message)(nil), (*messageRevision)(nil), (*messageReply)(nil)} {
+		_, err := tx.NewCreateTable().
+			Model(model).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return r, err
+		}
+	}
+	_, err := tx.NewCreateIndex().
+		Model((*messageRevision)(nil)).
+		Index("message_revisions_message_idx").
+		Column("message_id").
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return r, err
+	}
+	_, err = tx.NewCreateIndex().
+		Model((*messageReply)(nil)).
+		Index("message_replies_thread_idx").
+		Column("thread_id").
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+func (r *BunMessageRepository) Save(ctx context.Context, m server.MessageRecord) error {
+	tx := infra.ExtractTx(ctx, r.db)
+	msg := messageToDB(m)
+	return tx.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewInsert().
+			Model(msg).
+			Exec(ctx)
+		if err != nil || msg.Reply == nil {
+			return err
+		}
+		_, err = tx.NewInsert().
+			Model(msg.Reply).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (r *BunMessageRepository) GetByID(ctx context.Context, id uuid.UUID) (server.MessageRecord, error) {
+	tx := infra.ExtractTx(ctx, r.db)
+	m := &message{}
+	err := tx.NewSelect().
+		Model(m).
+		Relation("Reply").
+		Where("m.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = shared.ErrNotExist
+		}
+		return server.MessageRecord{}, err
+	}
+	return messageFromDB(*m), nil
+}
+
+func (r *BunMessageRepository) Update(ctx context.Context, m server.MessageRecord) error {
+	tx := infra.ExtractTx(ctx, r.db)
+	res, err := tx.NewUpdate().
+		Model(messageToDB(m)).
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return shared.ErrNotExist
+	}
+	return nil
+}
+
+func (r *BunMessageRepository) ListThread(ctx context.Context, q server.ThreadQuery) (server.MessageList, error) {
+	tx := infra.ExtractTx(ctx, r.db)
+	if q.Limit < 1 {
+		q.Limit = 50
+	}
+	var msgs []message
+	query := tx.NewSelect().
+		Model(&msgs).
+		Relation("Reply").
+		Where("reply.thread_id = ?", q.Root).
+		Limit(q.Limit+1).
+		Order("m.sent_at", "m.id")
+	if q.After != uuid.Nil {
+		after := tx.NewSelect().
+			Model((*message)(nil)).
+			Column("sent_at", "id").
+			Where("id = ?", q.After)
+		query = query.Where("(m.sent_at, m.id) > (?)", after)
+	}
+	if err := query.Scan(ctx); err != nil {
+		return server.MessageList{}, err
+	}
+
+	var next uuid.UUID
+	if len(msgs) > q.Limit {
+		msgs = msgs[:q.Limit]
+		next = msgs[len(msgs)-1].ID
+	}
+	list := server.MessageList{
+		Messages: make([]server.MessageRecord, len(msgs)),
+		Cursor:   next,
+	}
+	for i, m := range msgs {
+		list.Messages[i] = messageFromDB(m)
+	}
+	return list, nil
+}
+
+func (r *BunMessageRepository) AddRevision(ctx context.Context, rev server.MessageRevision) error {
+	tx := infra.ExtractTx(ctx, r.db)
+	_, err := tx.NewInsert().
+		Model(&messageRevision{
+			MessageID:  rev.MessageID,
+			Content:    rev.Content,
+			ReplacedAt: rev.ReplacedAt,
+		}).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type message struct {
+	bun.BaseModel `bun:"table:messages,alias:m"`
+
+	ID        uuid.UUID `bun:",pk"`
+	Sender    uuid.UUID `bun:",notnull"`
+	Recipient uuid.UUID `bun:",notnull"`
+	Content   string    `bun:",notnull"`
+	SentAt    time.Time `bun:",notnull"`
+	EditedAt  time.Time `bun:",nullzero"`
+	DeletedAt time.Time `bun:",nullzero"`
+
+	Reply *messageReply `bun:"rel:has-one,join:id=message_id"`
+}
+
+// messageReply places a message in a thread, for the messages that reply
+// to another.
+type messageReply struct {
+	bun.BaseModel `bun:"table:message_replies"`
+
+	MessageID uuid.UUID `bun:",pk"`
+	ReplyTo   uuid.UUID `bun:",notnull"`
+	ThreadID  uuid.UUID `bun:",notnull"`
+}
+
+type messageRevision struct {
+	bun.BaseModel `bun:"table:message_revisions,alias:mr"`
+
+	ID         int64     `bun:",pk,autoincrement"`
+	MessageID  uuid.UUID `bun:",notnull"`
+	Content    string    `bun:",notnull"`
+	ReplacedAt time.Time `bun:",notnull"`
+}
+
+func messageFromDB(m message) server.MessageRecord {
+	rec := server.MessageRecord{
+		ID:        m.ID,
+		Sender:    m.Sender,
+		Recipient: m.Recipient,
+		Content:   m.Content,
+		SentAt:    m.SentAt,
+		EditedAt:  m.EditedAt,
+		DeletedAt: m.DeletedAt,
+	}
+	if m.Reply != nil {
+		rec.ReplyTo = m.Reply.ReplyTo
+		rec.ThreadID = m.Reply.ThreadID
+	}
+	return rec
+}
+
+func messageToDB(m server.MessageRecord) *message {
+	msg := &message{
+		ID:        m.ID,
+		Sender:    m.Sender,
+		Recipient: m.Recipient,
+		Content:   m.Content,
+		SentAt:    m.SentAt,
+		EditedAt:  m.EditedAt,
+		DeletedAt: m.DeletedAt,
+	}
+	if m.ReplyTo != uuid.Nil {
+		msg.Reply = &messageReply{
+			MessageID: m.ID,
+			ReplyTo:   m.ReplyTo,
+			ThreadID:  m.ThreadID,
+		}
+	}
+	return msg
+}
@@ -0,0 +1,119 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+	"github.com/charadev96/gonec/internal/shared/infra"
+)
+
+// noticeBatchSize bounds the recipients inserted per statement, to stay
+// under the bound variable limit of sqlite.
+const noticeBatchSize = 500
+
+type BunNoticeRepository struct {
+	db *bun.DB
+}
+
+func NewBunNoticeRepository(ctx context.Context, db *bun.DB) (*BunNoticeRepository, error) {
+	r := &BunNoticeRepository{
+		db: db,
+	}
+	tx := infra.ExtractTx(ctx, r.db)
+	for _, model := range []any{(*notice)(nil), (*noticeRecipient)(nil)} {
+		_, err := tx.NewCreateTable().
+			Model(model).
+			IfNotExists().
+			Exec(ctx)
+		if err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
+func (r *BunNoticeRepository) Save(ctx context.Context, n shared.Notice, recipients []uuid.UUID) error {
+	tx := infra.ExtractTx(ctx, r.db)
+	_, err := tx.NewInsert().
+		Model(&notice{
+			ID:      n.ID,
+			Content: n.Content,
+			SentAt:  n.SentAt,
+		}).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	for len(recipients) > 0 {
+		batch := recipients[:min(len(recipients), noticeBatchSize)]
+		recipients = recipients[len(batch):]
+		rs := make([]noticeRecipient, 0, len(batch))
+		for _, id := range batch {
+			rs = append(rs, noticeRecipient{NoticeID: n.ID, UserID: id})
+		}
+		_, err := tx.NewInsert().
+			Model(&rs).
+			Ignore().
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *BunNoticeRepository) ListPending(ctx context.Context, user uuid.UUID) ([]shared.Notice, error) {
+	tx := infra.ExtractTx(ctx, r.db)
+	var ns []notice
+	err := tx.NewSelect().
+		Model(&ns).
+		Join("JOIN notice_recipients AS nr ON nr.notice_id = n.id").
+		Where("nr.user_id = ?", user).
+		Order("n.sent_at").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]shared.Notice, 0, len(ns))
+	for _, n := range ns {
+		list = append(list, shared.Notice{
+			ID:      n.ID,
+			Content: n.Content,
+			SentAt:  n.SentAt,
+		})
+	}
+	return list, nil
+}
+
+func (r *BunNoticeRepository) Claim(ctx context.Context, user, id uuid.UUID) (bool, error) {
+	tx := infra.ExtractTx(ctx, r.db)
+	res, err := tx.NewDelete().
+		Model(&noticeRecipient{NoticeID: id, UserID: user}).
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+type notice struct {
+	bun.BaseModel `bun:"table:notices,alias:n"`
+
+	ID      uuid.UUID `bun:",pk"`
+	Content string    `bun:",notnull"`
+	SentAt  time.Time `bun:",notnull"`
+}
+
+type noticeRecipient struct {
+	bun.BaseModel `bun:"table:notice_recipients"`
+
+	NoticeID uuid.UUID `bun:",pk"`
+	UserID   uuid.UUID `bun:",pk"`
+}
@@ -0,0 +1,94 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	server "github.com/charadev96/gonec/internal/server/domain"
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+	"github.com/charadev96/gonec/internal/shared/infra"
+)
+
+type BunPresenceRepository struct {
+	db *bun.DB
+}
+
+func NewBunPresenceRepository(ctx context.Context, db *bun.DB) (*BunPresenceRepository, error) {
+	r := &BunPresenceRepository{
+		db: db,
+	}
+	tx := infra.ExtractTx(ctx, r.db)
+	_, err := tx.NewCreateTable().
+		Model((*presence)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+func (r *BunPresenceRepository) Get(ctx context.Context, id uuid.UUID) (server.PresenceRecord, error) {
+	tx := infra.ExtractTx(ctx, r.db)
+	p := &presence{}
+	err := tx.NewSelect().
+		Model(p).
+		Where("user_id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = shared.ErrNotExist
+		}
+		return server.PresenceRecord{}, err
+	}
+	return presenceFromDB(*p), nil
+}
+
+func (r *BunPresenceRepository) UpdateLastSeen(ctx context.Context, id uuid.UUID, t time.Time) error {
+	tx := infra.ExtractTx(ctx, r.db)
+	p := &presence{UserID: id, LastSeen: t}
+	_, err := tx.NewInsert().
+		Model(p).
+		On("CONFLICT (user_id) DO UPDATE").
+		Set("last_seen = EXCLUDED.last_seen").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *BunPresenceRepository) UpdateHidden(ctx context.Context, id uuid.UUID, hidden bool) error {
+	tx := infra.ExtractTx(ctx, r.db)
+	p := &presence{UserID: id, Hidden: hidden}
+	_, err := tx.NewInsert().
+		Model(p).
+		On("CONFLICT (user_id) DO UPDATE").
+		Set("hidden = EXCLUDED.hidden").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type presence struct {
+	bun.BaseModel `bun:"table:presence"`
+
+	UserID   uuid.UUID `bun:",pk"`
+	LastSeen time.Time `bun:",nullzero"`
+	Hidden   bool      `bun:",notnull"`
+}
+
+func presenceFromDB(p presence) server.PresenceRecord {
+	return server.PresenceRecord{
+		UserID:   p.UserID,
+		LastSeen: p.LastSeen,
+		Hidden:   p.Hidden,
+	}
+}
@@ -0,0 +1,100 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	server "github.com/charadev96/gonec/internal/server/domain"
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+	"github.com/charadev96/gonec/internal/shared/infra"
+)
+
+type BunReactionRepository struct {
+	db *bun.DB
+}
+
+func NewBunReactionRepository(ctx context.Context, db *bun.DB) (*BunReactionRepository, error) {
+	r := &BunReactionRepository{
+		db: db,
+	}
+	tx := infra.ExtractTx(ctx, r.db)
+	_, err := tx.NewCreateTable().
+		Model((*reaction)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+func (r *BunReactionRepository) Add(ctx context.Context, rec server.ReactionRecord) error {
+	tx := infra.ExtractTx(ctx, r.db)
+	_, err := tx.NewInsert().
+		Model(&reaction{
+			MessageID: rec.MessageID,
+			UserID:    rec.UserID,
+			Emoji:     rec.Emoji,
+			CreatedAt: rec.CreatedAt,
+		}).
+		Ignore().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *BunReactionRepository) Remove(ctx context.Context, message, user uuid.UUID, emoji string) error {
+	tx := infra.ExtractTx(ctx, r.db)
+	_, err := tx.NewDelete().
+		Model((*reaction)(nil)).
+		Where("message_id = ?", message).
+		Where("user_id = ?", user).
+		Where("emoji = ?", emoji).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *BunReactionRepository) Counts(ctx context.Context, messages []uuid.UUID) (map[uuid.UUID][]shared.ReactionCount, error) {
+	counts := make(map[uuid.UUID][]shared.ReactionCount)
+	if len(messages) == 0 {
+		return counts, nil
+	}
+	tx := infra.ExtractTx(ctx, r.db)
+	var rows []struct {
+		MessageID uuid.UUID
+		Emoji     string
+		Count     int
+	}
+	err := tx.NewSelect().
+		Model((*reaction)(nil)).
+		Column("message_id", "emoji").
+		ColumnExpr("COUNT(*) AS count").
+		Where("message_id IN (?)", bun.In(messages)).
+		Group("message_id", "emoji").
+		OrderExpr("MIN(created_at)").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.MessageID] = append(counts[row.MessageID], shared.ReactionCount{Emoji: row.Emoji, Count: row.Count})
+	}
+	return counts, nil
+}
+
+type reaction struct {
+	bun.BaseModel `bun:"table:reactions"`
+
+	MessageID uuid.UUID `bun:",pk"`
+	UserID    uuid.UUID `bun:",pk"`
+	Emoji     string    `bun:",pk"`
+	CreatedAt time.Time `bun:",notnull"`
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	sharedpb "github.com/charadev96/gonec/gen/shared"
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+	pb "github.com/charadev96/gonec/internal/shared/pb"
+)
+
+const (
+	permRollover = 0644
+
+	pemKeyRollover = "GONEC KEY ROLLOVER"
+)
+
+// CreateKeyRollover signs newKey with the key at oldKeyPath and writes the
+// statement to path, for the gateway to serve once it runs on the new key.
+func CreateKeyRollover(
+	path, oldKeyPath string,
+	newKey ed25519.PublicKey,
+	notBefore, notAfter time.Time,
+) (shared.KeyRollover, error) {
+	if len(newKey) != ed25519.PublicKeySize {
+		return shared.KeyRollover{}, shared.NewError(shared.ErrInvalid, "bad public key length")
+	}
+	if !notAfter.After(notBefore) {
+		return shared.KeyRollover{}, shared.NewError(shared.ErrInvalid, "bad time period, NotAfter must be after NotBefore")
+	}
+	if !notAfter.After(time.Now()) {
+		return shared.KeyRollover{}, shared.NewError(shared.ErrInvalid, "bad time period, NotAfter must be in the future")
+	}
+	oldKey, _, err := loadKeyFile(oldKeyPath)
+	if err != nil {
+		return shared.KeyRollover{}, fmt.Errorf("load old key: %w", err)
+	}
+
+	r := shared.SignKeyRollover(oldKey, newKey, notBefore, notAfter)
+	raw, err := proto.Marshal(pb.KeyRolloverToPB(r))
+	if err != nil {
+		return shared.KeyRollover{}, fmt.Errorf("marshal rollover: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := pem.Encode(&buf, &pem.Block{Type: pemKeyRollover, Bytes: raw}); err != nil {
+		return shared.KeyRollover{}, fmt.Errorf("encode rollover: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), permRollover); err != nil {
+		return shared.KeyRollover{}, err
+	}
+	return r, nil
+}
+
+func LoadKeyRollover(path string) (shared.KeyRollover, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return shared.KeyRollover{}, err
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil || block.Type != pemKeyRollover {
+		return shared.KeyRollover{}, fmt.Errorf("bad rollover format, must be PEM")
+	}
+	msg := &sharedpb.KeyRollover{}
+	if err := proto.Unmarshal(block.Bytes, msg); err != nil {
+		return shared.KeyRollover{}, fmt.Errorf("unmarshal rollover: %w", err)
+	}
+	r, err := pb.KeyRolloverFromPB(msg)
+	if err != nil {
+		return shared.KeyRollover{}, fmt.Errorf("parse rollover: %w", err)
+	}
+	if err := r.Verify(r.NotBefore); err != nil {
+		return shared.KeyRollover{}, fmt.Errorf("verify rollover: %w", err)
+	}
+	return r, nil
+}
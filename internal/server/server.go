@@ -2,9 +2,13 @@ package server
 
 import (
 	"context"
+	"crypto/ed25519"
 	"crypto/tls"
+	"errors"
+	"expvar"
 	"fmt"
 	"net"
+	"net/http"
 	"time"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
@@ -18,37 +22,59 @@ import (
 	admin "github.com/charadev96/gonec/internal/server/handler/admin"
 	gateway "github.com/charadev96/gonec/internal/server/handler/gateway"
 	"github.com/charadev96/gonec/internal/server/service"
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+	"github.com/charadev96/gonec/internal/shared/handler"
 	"github.com/charadev96/gonec/internal/shared/log"
 )
 
 type AdminConfig struct {
-	Addr   string
-	Logger *zerolog.Logger
+	Addr string
+	// MetricsAddr, if set, is where the expvar metrics are served over
+	// HTTP, at /debug/vars, along with those of the server.
+	MetricsAddr string
+	Logger      *zerolog.Logger
 }
 
 type GatewayConfig struct {
 	Addr        string
-	Certificate tls.Certificate
-	Logger      *zerolog.Logger
+	Certificate *CertificateManager
+	// RolloverPath is where the admin KeyService writes key rollover
+	// statements, for LoadKeyRollover to read once the gateway runs on the
+	// new key.
+	RolloverPath string
+	Logger       *zerolog.Logger
 }
 
 type Server struct {
 	admin   AdminConfig
 	gateway GatewayConfig
 
-	user *service.UserService
-	chat *service.ChatService
+	user        *service.UserService
+	chat        *service.ChatService
+	attachments *service.AttachmentService
+
+	metrics *expvar.Map
 }
 
-func New(adm AdminConfig, gtw GatewayConfig, user *service.UserService, chat *service.ChatService) *Server {
+func New(
+	adm AdminConfig,
+	gtw GatewayConfig,
+	user *service.UserService,
+	chat *service.ChatService,
+	attachments *service.AttachmentService,
+) *Server {
 	l := zerolog.Nop()
 	s := &Server{
 		admin:   adm,
 		gateway: gtw,
 
-		user: user,
-		chat: chat,
+		user:        user,
+		chat:        chat,
+		attachments: attachments,
+
+		metrics: new(expvar.Map).Init(),
 	}
+	s.metrics.Set("inbox", chat.InboxMetrics())
 	if s.admin.Logger == nil {
 		s.admin.Logger = &l
 	}
@@ -67,12 +93,22 @@ func (s *Server) ServeAdmin(ctx context.Context) error {
 		Str("address", s.admin.Addr).
 		Msg("started server")
 
+	logger := log.NewInterceptor(*s.admin.Logger)
 	opts := []logging.Option{
 		logging.WithLogOnEvents(logging.StartCall, logging.FinishCall),
 	}
 	inst := grpc.NewServer(
-		grpc.UnaryInterceptor(
-			logging.UnaryServerInterceptor(log.NewInterceptor(*s.admin.Logger), opts...),
+		grpc.ChainUnaryInterceptor(
+			handler.UnaryRecoveryInterceptor(*s.admin.Logger),
+			log.UnaryRequestIDInterceptor(*s.admin.Logger),
+			logging.UnaryServerInterceptor(logger, opts...),
+			handler.UnaryErrorInterceptor(*s.admin.Logger),
+		),
+		grpc.ChainStreamInterceptor(
+			handler.StreamRecoveryInterceptor(*s.admin.Logger),
+			log.StreamRequestIDInterceptor(*s.admin.Logger),
+			logging.StreamServerInterceptor(logger, opts...),
+			handler.StreamErrorInterceptor(*s.admin.Logger),
 		),
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			Time:    30 * time.Second,
@@ -80,9 +116,15 @@ func (s *Server) ServeAdmin(ctx context.Context) error {
 		}),
 	)
 	adminpb.RegisterUserServiceServer(inst, admin.NewUserHandler(s.user))
+	adminpb.RegisterChatServiceServer(inst, admin.NewChatHandler(s.chat))
+	adminpb.RegisterKeyServiceServer(inst, admin.NewKeyHandler(s.createKeyRollover))
 
 	reflection.Register(inst)
 
+	if s.admin.MetricsAddr != "" {
+		go s.serveMetrics(ctx)
+	}
+
 	go func() {
 		<-ctx.Done()
 		s.admin.Logger.Info().Msg("shutting down")
@@ -94,8 +136,8 @@ func (s *Server) ServeAdmin(ctx context.Context) error {
 
 func (s *Server) ServeMessaging(ctx context.Context) error {
 	config := &tls.Config{
-		Certificates: []tls.Certificate{s.gateway.Certificate},
-		NextProtos:   []string{"h2"},
+		GetCertificate: s.gateway.Certificate.GetCertificate,
+		NextProtos:     []string{"h2"},
 	}
 	ln, err := tls.Listen("tcp", s.gateway.Addr, config)
 	if err != nil {
@@ -106,16 +148,33 @@ func (s *Server) ServeMessaging(ctx context.Context) error {
 		Str("address", s.gateway.Addr).
 		Msg("started server")
 
+	logger := log.NewInterceptor(*s.gateway.Logger)
 	opts := []logging.Option{
 		logging.WithLogOnEvents(logging.StartCall, logging.FinishCall),
+		logging.WithFieldsFromContext(log.IdentityFields),
 	}
 	inst := grpc.NewServer(
-		grpc.UnaryInterceptor(
-			logging.UnaryServerInterceptor(log.NewInterceptor(*s.admin.Logger), opts...),
+		grpc.ChainUnaryInterceptor(
+			handler.UnaryRecoveryInterceptor(*s.gateway.Logger),
+			log.UnaryRequestIDInterceptor(*s.gateway.Logger),
+			log.UnaryIdentityInterceptor(nil),
+			logging.UnaryServerInterceptor(logger, opts...),
+			handler.UnaryErrorInterceptor(*s.gateway.Logger),
+		),
+		grpc.ChainStreamInterceptor(
+			handler.StreamRecoveryInterceptor(*s.gateway.Logger),
+			log.StreamRequestIDInterceptor(*s.gateway.Logger),
+			log.StreamIdentityInterceptor(nil),
+			logging.StreamServerInterceptor(logger, opts...),
+			handler.StreamErrorInterceptor(*s.gateway.Logger),
 		),
 	)
+	go s.gateway.Certificate.Run(ctx)
+	go s.attachments.Run(ctx)
+
 	gatewaypb.RegisterAuthServiceServer(inst, gateway.NewAuthHandler(s.user))
 	gatewaypb.RegisterChatServiceServer(inst, gateway.NewChatHandler(ctx, s.chat))
+	gatewaypb.RegisterAttachmentServiceServer(inst, gateway.NewAttachmentHandler(ctx, s.attachments))
 
 	reflection.Register(inst)
 
@@ -127,3 +186,52 @@ func (s *Server) ServeMessaging(ctx context.Context) error {
 
 	return inst.Serve(ln)
 }
+
+func (s *Server) createKeyRollover(newKey ed25519.PublicKey, notBefore, notAfter time.Time) (shared.KeyRollover, error) {
+	if s.gateway.Certificate == nil || s.gateway.RolloverPath == "" {
+		return shared.KeyRollover{}, shared.NewError(shared.ErrFailedPrecondition, "key rollover is not configured")
+	}
+	return CreateKeyRollover(s.gateway.RolloverPath, s.gateway.Certificate.keyPath, newKey, notBefore, notAfter)
+}
+
+func (s *Server) serveMetrics(ctx context.Context) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/vars", s.handleMetrics)
+	srv := &http.Server{
+		Addr:              s.admin.MetricsAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		srv.Shutdown(context.WithoutCancel(ctx))
+	}()
+
+	s.admin.Logger.Info().
+		Str("address", s.admin.MetricsAddr).
+		Msg("started metrics server")
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		s.admin.Logger.Error().
+			Err(err).
+			Msg("failed to serve metrics")
+	}
+}
+
+// handleMetrics writes the published expvar metrics and those of the
+// server as one JSON object, as expvar.Handler does.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	fmt.Fprintf(w, "{\n")
+	first := true
+	write := func(kv expvar.KeyValue) {
+		if !first {
+			fmt.Fprintf(w, ",\n")
+		}
+		first = false
+		fmt.Fprintf(w, "%q: %s", kv.Key, kv.Value)
+	}
+	expvar.Do(write)
+	s.metrics.Do(write)
+	fmt.Fprintf(w, "\n}\n")
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	server "github.com/charadev96/gonec/internal/server/domain"
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+)
+
+const (
+	defaultAttachmentMaxSize = 25 << 20
+	defaultAttachmentQuota   = 256 << 20
+
+	// Attachments not sent to anyone within unsentAttachmentTTL of their
+	// upload are collected.
+	unsentAttachmentTTL  = 24 * time.Hour
+	attachmentGCInterval = time.Hour
+)
+
+type AttachmentService struct {
+	attachments server.AttachmentRepository
+	blobs       server.BlobStore
+	user        *UserService
+
+	maxSize int64
+	quota   int64
+	logger  *zerolog.Logger
+
+	// mu keeps a blob from being collected between storing it and saving
+	// the attachment referring to it, uploads hold it shared while they do.
+	mu sync.RWMutex
+}
+
+type AttachmentServiceOption func(*AttachmentService)
+
+func AttachmentWithMaxSize(n int64) AttachmentServiceOption {
+	return func(s *AttachmentService) {
+		s.maxSize = n
+	}
+}
+
+// AttachmentWithQuota limits the total size of the attachments a user has
+// on the server, until their recipients fetch them.
+func AttachmentWithQuota(n int64) AttachmentServiceOption {
+	return func(s *AttachmentService) {
+		s.quota = n
+	}
+}
+
+func AttachmentWithLogger(l *zerolog.Logger) AttachmentServiceOption {
+	return func(s *AttachmentService) {
+		s.logger = l
+	}
+}
+
+func NewAttachmentService(
+	a server.AttachmentRepository,
+	b server.BlobStore,
+	u *UserService,
+	opts ...AttachmentServiceOption,
+) *AttachmentService {
+	l := zerolog.Nop()
+	s := &AttachmentService{
+		attachments: a,
+		blobs:       b,
+		user:        u,
+
+		maxSize: defaultAttachmentMaxSize,
+		quota:   defaultAttachmentQuota,
+		logger:  &l,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Upload stores the contents of r as an attachment of the session user. The
+// quota is checked against the usage when the upload starts.
+func (s *AttachmentService) Upload(ctx context.Context, auth shared.Session, mimeType string, r io.Reader) (shared.Attachment, error) {
+	if err := s.user.VerifySession(ctx, auth); err != nil {
+		return shared.Attachment{}, fmt.Errorf("verify session: %w", err)
+	}
+
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	if _, _, err := mime.ParseMediaType(mimeType); err != nil {
+		return shared.Attachment{}, shared.NewError(shared.ErrInvalid, "bad mime type")
+	}
+
+	usage, err := s.attachments.Usage(ctx, auth.UserID)
+	if err != nil {
+		return shared.Attachment{}, fmt.Errorf("get usage: %w", err)
+	}
+	limit := min(s.maxSize, s.quota-usage)
+	if limit <= 0 {
+		return shared.Attachment{}, shared.NewError(shared.ErrResourceExhausted, "attachment quota exceeded")
+	}
+
+	blob, err := s.blobs.Put(ctx, r, limit)
+	if errors.Is(err, shared.ErrResourceExhausted) {
+		if limit == s.maxSize {
+			return shared.Attachment{}, shared.NewError(shared.ErrResourceExhausted, "attachment too large")
+		}
+		return shared.Attachment{}, shared.NewError(shared.ErrResourceExhausted, "attachment quota exceeded")
+	}
+	if err != nil {
+		return shared.Attachment{}, fmt.Errorf("stage blob: %w", err)
+	}
+	defer blob.Discard()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if err := blob.Commit(); err != nil {
+		return shared.Attachment{}, fmt.Errorf("store blob: %w", err)
+	}
+	a := server.Attachment{
+		Attachment: shared.Attachment{
+			ID:       uuid.New(),
+			Size:     blob.Size(),
+			MIMEType: mimeType,
+			Hash:     blob.Hash(),
+		},
+		Owner:     auth.UserID,
+		CreatedAt: time.Now(),
+	}
+	if err := s.attachments.Save(ctx, a); err != nil {
+		return shared.Attachment{}, fmt.Errorf("save attachment: %w", err)
+	}
+	return a.Attachment, nil
+}
+
+// Attach adds to as a recipient of the attachments ids of from, and returns
+// them.
+func (s *AttachmentService) Attach(ctx context.Context, from, to uuid.UUID, ids []uuid.UUID) ([]shared.Attachment, error) {
+	as := make([]shared.Attachment, 0, len(ids))
+	for _, id := range ids {
+		a, err := s.attachments.GetByID(ctx, id)
+		if err == nil && a.Owner != from {
+			err = shared.ErrNotExist
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get attachment %s: %w", id, err)
+		}
+		as = append(as, a.Attachment)
+	}
+
+	for _, a := range as {
+		err := s.attachments.AddRecipient(ctx, server.AttachmentRecipient{
+			AttachmentID: a.ID,
+			UserID:       to,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("add recipient: %w", err)
+		}
+	}
+	return as, nil
+}
+
+// Open opens attachment id for reading by the session user, who must own
+// it or have been sent it.
+func (s *AttachmentService) Open(ctx context.Context, auth shared.Session, id uuid.UUID) (shared.Attachment, io.ReadCloser, error) {
+	if err := s.user.VerifySession(ctx, auth); err != nil {
+		return shared.Attachment{}, nil, fmt.Errorf("verify session: %w", err)
+	}
+
+	a, err := s.attachments.GetByID(ctx, id)
+	if err != nil {
+		return shared.Attachment{}, nil, fmt.Errorf("get attachment: %w", err)
+	}
+	if a.Owner != auth.UserID {
+		if _, err := s.attachments.GetRecipient(ctx, id, auth.UserID); err != nil {
+			return shared.Attachment{}, nil, fmt.Errorf("get recipient: %w", err)
+		}
+	}
+
+	r, err := s.blobs.Open(a.Hash)
+	if err != nil {
+		return shared.Attachment{}, nil, fmt.Errorf("open blob: %w", err)
+	}
+	return a.Attachment, r, nil
+}
+
+// AckDownload records that the session user received attachment id in
+// full, and collects it once every recipient has.
+func (s *AttachmentService) AckDownload(ctx context.Context, auth shared.Session, id uuid.UUID) error {
+	if err := s.user.VerifySession(ctx, auth); err != nil {
+		return fmt.Errorf("verify session: %w", err)
+	}
+
+	a, err := s.attachments.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get attachment: %w", err)
+	}
+	if a.Owner == auth.UserID {
+		return nil
+	}
+	if _, err := s.attachments.GetRecipient(ctx, id, auth.UserID); err != nil {
+		return fmt.Errorf("get recipient: %w", err)
+	}
+
+	if err := s.attachments.MarkFetched(ctx, id, auth.UserID); err != nil {
+		return fmt.Errorf("mark fetched: %w", err)
+	}
+	n, err := s.attachments.CountUnfetched(ctx, id)
+	if err != nil {
+		return fmt.Errorf("count unfetched: %w", err)
+	}
+	if n > 0 {
+		return nil
+	}
+	return s.collect(ctx, a)
+}
+
+// Run collects the attachments left unsent until ctx is done.
+func (s *AttachmentService) Run(ctx context.Context) {
+	ticker := time.NewTicker(attachmentGCInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.collectUnsent(ctx); err != nil {
+				s.logger.Error().
+					Err(err).
+					Msg("failed to collect unsent attachments")
+			}
+		}
+	}
+}
+
+func (s *AttachmentService) collectUnsent(ctx context.Context) error {
+	as, err := s.attachments.ListUnsent(ctx, time.Now().Add(-unsentAttachmentTTL))
+	if err != nil {
+		return fmt.Errorf("list unsent: %w", err)
+	}
+	for _, a := range as {
+		if err := s.collect(ctx, a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collect deletes attachment a, and its blob unless another attachment has
+// the same contents.
+func (s *AttachmentService) collect(ctx context.Context, a server.Attachment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.attachments.Delete(ctx, a.ID); err != nil {
+		return fmt.Errorf("delete attachment: %w", err)
+	}
+	n, err := s.attachments.CountByHash(ctx, a.Hash)
+	if err != nil {
+		return fmt.Errorf("count blob references: %w", err)
+	}
+	if n > 0 {
+		return nil
+	}
+	if err := s.blobs.Delete(a.Hash); err != nil {
+		return fmt.Errorf("delete blob: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+)
+
+func TestAttachmentKeptUntilAcknowledged(t *testing.T) {
+	ctx := context.Background()
+	f := newChatFixture(t)
+	alice, bob, eve := f.login(t), f.login(t), f.login(t)
+
+	a, err := f.attachments.Upload(ctx, alice, "text/plain", strings.NewReader("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.chat.Send(ctx, alice, bob.UserID.String(), "see attached", []uuid.UUID{a.ID}, uuid.Nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// A download cut short is not acknowledged, and can be retried.
+	for range 2 {
+		_, r, err := f.attachments.Open(ctx, bob, a.ID)
+		if err != nil {
+			t.Fatalf("open before ack: %v", err)
+		}
+		r.Close()
+	}
+
+	if err := f.attachments.AckDownload(ctx, eve, a.ID); !errors.Is(err, shared.ErrNotExist) {
+		t.Fatalf("ack by a stranger: got %v, want ErrNotExist", err)
+	}
+	if err := f.attachments.AckDownload(ctx, alice, a.ID); err != nil {
+		t.Fatalf("ack by the owner: %v", err)
+	}
+	if _, r, err := f.attachments.Open(ctx, alice, a.ID); err != nil {
+		t.Fatalf("open after owner ack: %v", err)
+	} else {
+		r.Close()
+	}
+
+	if err := f.attachments.AckDownload(ctx, bob, a.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := f.attachments.Open(ctx, alice, a.ID); !errors.Is(err, shared.ErrNotExist) {
+		t.Fatalf("open after every recipient acked: got %v, want ErrNotExist", err)
+	}
+}
+
+func TestSlowUploadDoesNotHoldCollection(t *testing.T) {
+	ctx := context.Background()
+	f := newChatFixture(t)
+	alice, bob := f.login(t), f.login(t)
+
+	a, err := f.attachments.Upload(ctx, alice, "", strings.NewReader("done"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.chat.Send(ctx, alice, bob.UserID.String(), "", []uuid.UUID{a.ID}, uuid.Nil); err != nil {
+		t.Fatal(err)
+	}
+
+	pr, pw := io.Pipe()
+	uploaded := make(chan error, 1)
+	go func() {
+		_, err := f.attachments.Upload(ctx, alice, "", pr)
+		uploaded <- err
+	}()
+	if _, err := pw.Write([]byte("slow")); err != nil {
+		t.Fatal(err)
+	}
+
+	acked := make(chan error, 1)
+	go func() { acked <- f.attachments.AckDownload(ctx, bob, a.ID) }()
+	select {
+	case err := <-acked:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("collection waited for an upload in progress")
+	}
+
+	pw.Close()
+	if err := <-uploaded; err != nil {
+		t.Fatalf("slow upload: %v", err)
+	}
+}
@@ -0,0 +1,350 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+
+	server "github.com/charadev96/gonec/internal/server/domain"
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+)
+
+var eventQueueSize = 128
+
+type Lock struct{}
+
+var errInboxFull = shared.NewError(shared.ErrResourceExhausted, "recipient inbox is full")
+
+// EventBroker queues the events of each user in an inbox of bounded size
+// until they are streamed.
+type EventBroker struct {
+	inboxes map[uuid.UUID]*inbox
+	mu      chan Lock
+
+	size     int
+	overflow server.OverflowPolicy
+	spill    server.InboxRepository
+
+	dropped  expvar.Int
+	rejected expvar.Int
+	metrics  *expvar.Map
+}
+
+type inbox struct {
+	ch chan shared.Event
+	// spilled counts the events waiting in the spill store, which go
+	// before any new one.
+	spilled int
+	// reserved counts the room held by reservations with OverflowReject.
+	reserved int
+	refill   sync.Mutex
+}
+
+type EventBrokerOption func(*EventBroker)
+
+// BrokerWithInboxSize sets how many events each user can have queued.
+func BrokerWithInboxSize(n int) EventBrokerOption {
+	return func(b *EventBroker) {
+		b.size = n
+	}
+}
+
+func BrokerWithOverflowPolicy(p server.OverflowPolicy) EventBrokerOption {
+	return func(b *EventBroker) {
+		b.overflow = p
+	}
+}
+
+// BrokerWithInboxRepository sets where events are spilled with
+// OverflowSpill, which falls back to OverflowReject without one.
+func BrokerWithInboxRepository(r server.InboxRepository) EventBrokerOption {
+	return func(b *EventBroker) {
+		b.spill = r
+	}
+}
+
+func NewEventBroker(opts ...EventBrokerOption) *EventBroker {
+	b := &EventBroker{
+		inboxes: make(map[uuid.UUID]*inbox),
+		mu:      make(chan Lock, 1),
+
+		size:     eventQueueSize,
+		overflow: server.OverflowReject,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.overflow == server.OverflowSpill && b.spill == nil {
+		b.overflow = server.OverflowReject
+	}
+
+	b.metrics = new(expvar.Map).Init()
+	b.metrics.Set("queued", expvar.Func(func() any {
+		return b.sumInboxes(func(in *inbox) int { return len(in.ch) })
+	}))
+	b.metrics.Set("spilled", expvar.Func(func() any {
+		return b.sumInboxes(func(in *inbox) int { return in.spilled })
+	}))
+	b.metrics.Set("depth", expvar.Func(b.depths))
+	b.metrics.Set("dropped", &b.dropped)
+	b.metrics.Set("rejected", &b.rejected)
+	return b
+}
+
+// Metrics reports the events queued in and spilled from the inboxes, the
+// users with events waiting and how many, and the events dropped or
+// rejected since start because an inbox was full.
+func (b *EventBroker) Metrics() expvar.Var {
+	return b.metrics
+}
+
+// Get returns the inbox of id. Whoever reads from it calls Refill after
+// each event.
+func (b *EventBroker) Get(ctx context.Context, id uuid.UUID) (<-chan shared.Event, error) {
+	in, err := b.inbox(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return in.ch, nil
+}
+
+// Publish queues ev in the inbox of id, applying the overflow policy if it
+// is full.
+func (b *EventBroker) Publish(ctx context.Context, id uuid.UUID, ev shared.Event) error {
+	in, err := b.inbox(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := b.lock(ctx); err != nil {
+		return err
+	}
+	defer b.unlock()
+	return b.publish(ctx, id, in, ev)
+}
+
+// Reserve holds room for one event in the inbox of each of ids, failing as
+// Publish would if one of them has none. Senders reserve before storing
+// what the event tells, so that a full inbox does not leave it stored but
+// never sent.
+func (b *EventBroker) Reserve(ctx context.Context, ids ...uuid.UUID) (*Reservation, error) {
+	r := &Reservation{
+		broker:  b,
+		ids:     ids,
+		inboxes: make([]*inbox, len(ids)),
+	}
+	for i, id := range ids {
+		in, err := b.inbox(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		r.inboxes[i] = in
+	}
+	// The other policies always make room.
+	if b.overflow != server.OverflowReject {
+		return r, nil
+	}
+
+	if err := b.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer b.unlock()
+	for i, in := range r.inboxes {
+		if len(in.ch)+in.reserved >= cap(in.ch) {
+			for _, in := range r.inboxes[:i] {
+				in.reserved--
+			}
+			b.rejected.Add(1)
+			return nil, errInboxFull
+		}
+		in.reserved++
+	}
+	r.held = true
+	return r, nil
+}
+
+// publish queues ev in the inbox in of id. The caller holds the lock.
+func (b *EventBroker) publish(ctx context.Context, id uuid.UUID, in *inbox, ev shared.Event) error {
+	// Only senders hold the lock, so the room seen stays free.
+	if in.spilled == 0 && len(in.ch)+in.reserved < cap(in.ch) {
+		in.ch <- ev
+		return nil
+	}
+
+	switch b.overflow {
+	case server.OverflowDropOldest:
+		select {
+		case <-in.ch:
+			b.dropped.Add(1)
+		default:
+		}
+		in.ch <- ev
+		return nil
+	case server.OverflowSpill:
+		if err := b.spill.Push(ctx, id, ev); err != nil {
+			return fmt.Errorf("spill event: %w", err)
+		}
+		in.spilled++
+		return nil
+	default:
+		b.rejected.Add(1)
+		return errInboxFull
+	}
+}
+
+// Reservation is room held in the inboxes of some users, used by Publish or
+// given back by Release.
+type Reservation struct {
+	broker  *EventBroker
+	ids     []uuid.UUID
+	inboxes []*inbox
+	held    bool
+}
+
+// Publish queues ev in each reserved inbox.
+func (r *Reservation) Publish(ctx context.Context, ev shared.Event) error {
+	b := r.broker
+	if err := b.lock(ctx); err != nil {
+		return err
+	}
+	defer b.unlock()
+	var first error
+	for i, in := range r.inboxes {
+		if r.held {
+			in.reserved--
+		}
+		if err := b.publish(ctx, r.ids[i], in, ev); err != nil && first == nil {
+			first = err
+		}
+	}
+	r.held = false
+	return first
+}
+
+// Release gives back the room if Publish did not use it. It is a no-op
+// otherwise, so that it can be deferred.
+func (r *Reservation) Release() {
+	if !r.held {
+		return
+	}
+	b := r.broker
+	b.mu <- Lock{}
+	defer b.unlock()
+	for _, in := range r.inboxes {
+		in.reserved--
+	}
+	r.held = false
+}
+
+// Refill moves the spilled events of id back into its inbox as far as there
+// is room.
+func (b *EventBroker) Refill(ctx context.Context, id uuid.UUID) error {
+	in, err := b.inbox(ctx, id)
+	if err != nil {
+		return err
+	}
+	in.refill.Lock()
+	defer in.refill.Unlock()
+
+	if err := b.lock(ctx); err != nil {
+		return err
+	}
+	n := min(cap(in.ch)-len(in.ch), in.spilled)
+	b.unlock()
+	if n <= 0 {
+		return nil
+	}
+
+	// The store is read unlocked, so that senders spilling meanwhile do
+	// not wait on it. They only append, and nobody else sends to the inbox
+	// while events are spilled.
+	evs, err := b.spill.Pop(ctx, id, n)
+	if err != nil {
+		return fmt.Errorf("pop spilled events: %w", err)
+	}
+
+	b.mu <- Lock{}
+	defer b.unlock()
+	for _, ev := range evs {
+		in.ch <- ev
+	}
+	in.spilled -= len(evs)
+	return nil
+}
+
+func (b *EventBroker) inbox(ctx context.Context, id uuid.UUID) (*inbox, error) {
+	if err := b.lock(ctx); err != nil {
+		return nil, err
+	}
+	in, ok := b.inboxes[id]
+	b.unlock()
+	if ok {
+		return in, nil
+	}
+
+	// Events spilled before a restart are still in the store.
+	var spilled int
+	if b.overflow == server.OverflowSpill {
+		n, err := b.spill.Count(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("count spilled events: %w", err)
+		}
+		spilled = n
+	}
+
+	if err := b.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer b.unlock()
+	in, ok = b.inboxes[id]
+	if !ok {
+		in = &inbox{
+			ch:      make(chan shared.Event, b.size),
+			spilled: spilled,
+		}
+		b.inboxes[id] = in
+	}
+	return in, nil
+}
+
+func (b *EventBroker) lock(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case b.mu <- Lock{}:
+		return nil
+	}
+}
+
+func (b *EventBroker) unlock() {
+	<-b.mu
+}
+
+func (b *EventBroker) eachInbox(fn func(uuid.UUID, *inbox)) {
+	b.mu <- Lock{}
+	defer b.unlock()
+	for id, in := range b.inboxes {
+		fn(id, in)
+	}
+}
+
+func (b *EventBroker) sumInboxes(fn func(*inbox) int) int {
+	var n int
+	b.eachInbox(func(_ uuid.UUID, in *inbox) {
+		n += fn(in)
+	})
+	return n
+}
+
+// depths returns the number of events waiting for each user who has any.
+func (b *EventBroker) depths() any {
+	depths := make(map[string]int)
+	b.eachInbox(func(id uuid.UUID, in *inbox) {
+		if n := len(in.ch) + in.spilled; n > 0 {
+			depths[id.String()] = n
+		}
+	})
+	return depths
+}
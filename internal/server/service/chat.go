@@ -2,7 +2,13 @@ package service
 
 import (
 	"context"
+	"errors"
+	"expvar"
 	"fmt"
+	"slices"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 
@@ -10,110 +16,846 @@ import (
 	shared "github.com/charadev96/gonec/internal/shared/domain"
 )
 
-var msgQueueSize = 128
+const (
+	defaultEditWindow = 15 * time.Minute
+	maxReactionSize   = 32
+)
+
+type ChatService struct {
+	users       server.UserRepository
+	presence    server.PresenceRepository
+	messages    server.MessageRepository
+	reactions   server.ReactionRepository
+	blocks      server.BlockRepository
+	notices     server.NoticeRepository
+	user        *UserService
+	attachments *AttachmentService
 
-type Lock struct{}
+	events *EventBroker
+	online *PresenceTracker
 
-type MessageBroker struct {
-	inboxes map[uuid.UUID]chan shared.Message
-	mu      chan Lock
+	editWindow  time.Duration
+	blockPolicy server.BlockPolicy
 }
 
-func NewMessageBroker() *MessageBroker {
-	return &MessageBroker{
-		inboxes: make(map[uuid.UUID]chan shared.Message),
-		mu:      make(chan Lock, 1),
+type ChatServiceOption func(*ChatService)
+
+// ChatWithEditWindow sets how long after sending a message its sender may
+// edit or delete it.
+func ChatWithEditWindow(d time.Duration) ChatServiceOption {
+	return func(s *ChatService) {
+		s.editWindow = d
 	}
 }
 
-func (b *MessageBroker) Get(ctx context.Context, id uuid.UUID) (chan shared.Message, error) {
-	select {
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	case b.mu <- Lock{}:
-		defer func() { <-b.mu }()
+// ChatWithEventBroker sets the broker queueing the events of each user,
+// which decides what happens once their inbox is full.
+func ChatWithEventBroker(b *EventBroker) ChatServiceOption {
+	return func(s *ChatService) {
+		s.events = b
+	}
+}
+
+// ChatWithBlockPolicy sets what happens to messages sent to a user who
+// blocked the sender.
+func ChatWithBlockPolicy(p server.BlockPolicy) ChatServiceOption {
+	return func(s *ChatService) {
+		s.blockPolicy = p
+	}
+}
+
+func NewChatService(
+	r server.UserRepository,
+	p server.PresenceRepository,
+	m server.MessageRepository,
+	rc server.ReactionRepository,
+	b server.BlockRepository,
+	n server.NoticeRepository,
+	s *UserService,
+	a *AttachmentService,
+	opts ...ChatServiceOption,
+) *ChatService {
+	c := &ChatService{
+		users:       r,
+		presence:    p,
+		messages:    m,
+		reactions:   rc,
+		blocks:      b,
+		notices:     n,
+		user:        s,
+		attachments: a,
+		online:      NewPresenceTracker(),
+
+		editWindow: defaultEditWindow,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.events == nil {
+		c.events = NewEventBroker()
+	}
+	return c
+}
+
+// InboxMetrics reports on the inboxes of the event broker, as
+// EventBroker.Metrics does.
+func (s *ChatService) InboxMetrics() expvar.Var {
+	return s.events.Metrics()
+}
+
+// Send delivers str with the attachments of the session user with the
+// given IDs to the user named by to, either an ID or a name, and returns
+// the message as delivered. Unless replyTo is zero, the message replies to
+// that one, which must be in the same conversation.
+func (s *ChatService) Send(ctx context.Context, auth shared.Session, to string, str string, attachments []uuid.UUID, replyTo uuid.UUID) (shared.Message, error) {
+	err := s.user.VerifySession(ctx, auth)
+	if err != nil {
+		return shared.Message{}, fmt.Errorf("verify session: %w", err)
+	}
+	return s.deliver(ctx, auth.UserID, to, str, attachments, replyTo)
+}
+
+func (s *ChatService) deliver(ctx context.Context, from uuid.UUID, to string, str string, attachments []uuid.UUID, replyTo uuid.UUID) (shared.Message, error) {
+	user, err := s.resolve(ctx, to)
+	if err != nil {
+		return shared.Message{}, fmt.Errorf("get recipient: %w", err)
+	}
+
+	var thread uuid.UUID
+	if replyTo != uuid.Nil {
+		parent, err := s.messages.GetByID(ctx, replyTo)
+		if err == nil && !inConversation(parent, from, user.ID) {
+			err = shared.ErrNotExist
+		}
+		if errors.Is(err, shared.ErrNotExist) {
+			return shared.Message{}, shared.NewError(shared.ErrInvalid, "replied message is not in this conversation")
+		}
+		if err != nil {
+			return shared.Message{}, fmt.Errorf("get replied message: %w", err)
+		}
+		thread = parent.ThreadID
+		if thread == uuid.Nil {
+			thread = parent.ID
+		}
+	}
+
+	msg := shared.Message{
+		ID:        uuid.New(),
+		Sender:    from,
+		Recipient: user.ID,
+		Content:   str,
+		SentAt:    time.Now(),
+		ReplyTo:   replyTo,
+		ThreadID:  thread,
+	}
+	drop, err := s.checkBlocked(ctx, user.ID, from)
+	if err != nil || drop {
+		return msg, err
+	}
+
+	res, err := s.events.Reserve(ctx, user.ID)
+	if err != nil {
+		return shared.Message{}, err
+	}
+	defer res.Release()
+
+	msg.Attachments, err = s.attachments.Attach(ctx, from, user.ID, attachments)
+	if err != nil {
+		return shared.Message{}, fmt.Errorf("attach: %w", err)
+	}
+	err = s.messages.Save(ctx, server.MessageRecord{
+		ID:        msg.ID,
+		Sender:    msg.Sender,
+		Recipient: msg.Recipient,
+		Content:   msg.Content,
+		SentAt:    msg.SentAt,
+		ReplyTo:   msg.ReplyTo,
+		ThreadID:  msg.ThreadID,
+	})
+	if err != nil {
+		return shared.Message{}, fmt.Errorf("save message: %w", err)
+	}
+
+	if err := res.Publish(ctx, shared.Event{Message: &msg}); err != nil {
+		return shared.Message{}, err
+	}
+	return msg, nil
+}
 
-		var ok bool
-		c, ok := b.inboxes[id]
-		if !ok {
-			c = make(chan shared.Message, msgQueueSize)
-			b.inboxes[id] = c
+// EditMessage replaces the content of message id, sent by the session user
+// within the edit window, and tells its recipient.
+func (s *ChatService) EditMessage(ctx context.Context, auth shared.Session, id uuid.UUID, str string) (shared.MessageEdit, error) {
+	var edit shared.MessageEdit
+	err := s.revise(ctx, auth, id, func(m *server.MessageRecord, now time.Time) shared.Event {
+		m.Content = str
+		m.EditedAt = now
+		edit = shared.MessageEdit{
+			ID:       m.ID,
+			Sender:   m.Sender,
+			Content:  str,
+			EditedAt: now,
 		}
-		return c, nil
+		return shared.Event{Edit: &edit}
+	})
+	if err != nil {
+		return shared.MessageEdit{}, err
 	}
+	return edit, nil
 }
 
-type ChatService struct {
-	users server.UserRepository
-	user  *UserService
+// DeleteMessage retracts message id, sent by the session user within the
+// edit window, and tells its recipient.
+func (s *ChatService) DeleteMessage(ctx context.Context, auth shared.Session, id uuid.UUID) (shared.MessageDelete, error) {
+	var del shared.MessageDelete
+	err := s.revise(ctx, auth, id, func(m *server.MessageRecord, now time.Time) shared.Event {
+		m.Content = ""
+		m.DeletedAt = now
+		del = shared.MessageDelete{
+			ID:        m.ID,
+			Sender:    m.Sender,
+			DeletedAt: now,
+		}
+		return shared.Event{Delete: &del}
+	})
+	if err != nil {
+		return shared.MessageDelete{}, err
+	}
+	return del, nil
+}
+
+// React puts emoji on message id for the session user, or takes it off if
+// remove is set, and tells both parties of the message. The user must be
+// one of them.
+func (s *ChatService) React(ctx context.Context, auth shared.Session, id uuid.UUID, emoji string, remove bool) (shared.Reaction, error) {
+	if err := s.user.VerifySession(ctx, auth); err != nil {
+		return shared.Reaction{}, fmt.Errorf("verify session: %w", err)
+	}
+	if !validReaction(emoji) {
+		return shared.Reaction{}, shared.NewError(shared.ErrInvalid, "bad reaction")
+	}
 
-	msgs *MessageBroker
+	m, err := s.messages.GetByID(ctx, id)
+	if err == nil && (!m.DeletedAt.IsZero() || (m.Sender != auth.UserID && m.Recipient != auth.UserID)) {
+		err = shared.ErrNotExist
+	}
+	if err != nil {
+		return shared.Reaction{}, fmt.Errorf("get message: %w", err)
+	}
+
+	r := shared.Reaction{
+		MessageID: id,
+		UserID:    auth.UserID,
+		Emoji:     emoji,
+		Removed:   remove,
+		ReactedAt: time.Now(),
+	}
+	other := m.Sender
+	if other == auth.UserID {
+		other = m.Recipient
+	}
+	drop, err := s.checkBlocked(ctx, other, auth.UserID)
+	if err != nil || drop {
+		return r, err
+	}
+	res, err := s.events.Reserve(ctx, participants(m)...)
+	if err != nil {
+		return shared.Reaction{}, err
+	}
+	defer res.Release()
+
+	if remove {
+		err = s.reactions.Remove(ctx, id, auth.UserID, emoji)
+	} else {
+		err = s.reactions.Add(ctx, server.ReactionRecord{
+			MessageID: id,
+			UserID:    auth.UserID,
+			Emoji:     emoji,
+			CreatedAt: r.ReactedAt,
+		})
+	}
+	if err != nil {
+		return shared.Reaction{}, fmt.Errorf("save reaction: %w", err)
+	}
+
+	if err := res.Publish(ctx, shared.Event{Reaction: &r}); err != nil {
+		return shared.Reaction{}, err
+	}
+	return r, nil
 }
 
-func NewChatService(r server.UserRepository, s *UserService) *ChatService {
-	return &ChatService{
-		users: r,
-		user:  s,
-		msgs:  NewMessageBroker(),
+// validReaction accepts a short printable string, such as an emoji with
+// its modifiers.
+func validReaction(emoji string) bool {
+	if emoji == "" || len(emoji) > maxReactionSize || !utf8.ValidString(emoji) {
+		return false
+	}
+	for _, r := range emoji {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return false
+		}
 	}
+	return true
 }
 
-func (s *ChatService) Send(ctx context.Context, auth shared.Session, to uuid.UUID, str string) error {
-	err := s.user.VerifySession(ctx, auth)
+// ListThread lists the replies in the thread of message id, which the
+// session user must have sent or received, oldest first. Deleted replies are
+// listed as tombstones, so that the replies to them keep their place.
+func (s *ChatService) ListThread(ctx context.Context, auth shared.Session, id uuid.UUID, after uuid.UUID, limit int) (shared.MessagePage, error) {
+	if err := s.user.VerifySession(ctx, auth); err != nil {
+		return shared.MessagePage{}, fmt.Errorf("verify session: %w", err)
+	}
+
+	m, err := s.messages.GetByID(ctx, id)
+	if err == nil && m.Sender != auth.UserID && m.Recipient != auth.UserID {
+		err = shared.ErrNotExist
+	}
 	if err != nil {
-		return fmt.Errorf("verify session: %w", err)
+		return shared.MessagePage{}, fmt.Errorf("get message: %w", err)
+	}
+	root := m.ThreadID
+	if root == uuid.Nil {
+		root = m.ID
 	}
 
-	_, err = s.users.GetByID(ctx, to)
+	list, err := s.messages.ListThread(ctx, server.ThreadQuery{
+		Root:  root,
+		After: after,
+		Limit: limit,
+	})
 	if err != nil {
-		return fmt.Errorf("get recipient: %w", err)
+		return shared.MessagePage{}, fmt.Errorf("list thread: %w", err)
 	}
+	ids := make([]uuid.UUID, len(list.Messages))
+	for i, m := range list.Messages {
+		ids[i] = m.ID
+	}
+	counts, err := s.reactions.Counts(ctx, ids)
+	if err != nil {
+		return shared.MessagePage{}, fmt.Errorf("count reactions: %w", err)
+	}
+	page := shared.MessagePage{
+		Messages: make([]shared.Message, len(list.Messages)),
+		Cursor:   list.Cursor,
+	}
+	for i, m := range list.Messages {
+		page.Messages[i] = shared.Message{
+			ID:        m.ID,
+			Sender:    m.Sender,
+			Recipient: m.Recipient,
+			Content:   m.Content,
+			SentAt:    m.SentAt,
+			EditedAt:  m.EditedAt,
+			DeletedAt: m.DeletedAt,
+			Reactions: counts[m.ID],
+			ReplyTo:   m.ReplyTo,
+			ThreadID:  m.ThreadID,
+		}
+	}
+	return page, nil
+}
 
-	ch, err := s.msgs.Get(ctx, to)
+// inConversation reports whether m went between a and b, either way.
+func inConversation(m server.MessageRecord, a, b uuid.UUID) bool {
+	return (m.Sender == a && m.Recipient == b) || (m.Sender == b && m.Recipient == a)
+}
+
+func participants(m server.MessageRecord) []uuid.UUID {
+	if m.Sender == m.Recipient {
+		return []uuid.UUID{m.Sender}
+	}
+	return []uuid.UUID{m.Sender, m.Recipient}
+}
+
+// revise applies fn to message id of the session user, keeping the content
+// it replaces as a revision, and tells the recipient the event fn returns.
+func (s *ChatService) revise(ctx context.Context, auth shared.Session, id uuid.UUID, fn func(*server.MessageRecord, time.Time) shared.Event) error {
+	if err := s.user.VerifySession(ctx, auth); err != nil {
+		return fmt.Errorf("verify session: %w", err)
+	}
+
+	var res *Reservation
+	var ev shared.Event
+	err := s.user.txRunner.Exec(ctx, func(ctx context.Context) error {
+		m, err := s.messages.GetByID(ctx, id)
+		if err == nil && (m.Sender != auth.UserID || !m.DeletedAt.IsZero()) {
+			err = shared.ErrNotExist
+		}
+		if err != nil {
+			return fmt.Errorf("get message: %w", err)
+		}
+		now := time.Now()
+		if now.Sub(m.SentAt) > s.editWindow {
+			return shared.NewError(shared.ErrFailedPrecondition, "message can no longer be changed")
+		}
+		res, err = s.events.Reserve(ctx, m.Recipient)
+		if err != nil {
+			return err
+		}
+
+		err = s.messages.AddRevision(ctx, server.MessageRevision{
+			MessageID:  m.ID,
+			Content:    m.Content,
+			ReplacedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("add revision: %w", err)
+		}
+		ev = fn(&m, now)
+		if err := s.messages.Update(ctx, m); err != nil {
+			return fmt.Errorf("update message: %w", err)
+		}
+		return nil
+	})
+	if res != nil {
+		defer res.Release()
+	}
 	if err != nil {
 		return err
 	}
+	return res.Publish(ctx, ev)
+}
+
+func (s *ChatService) publish(ctx context.Context, to uuid.UUID, ev shared.Event) error {
+	return s.events.Publish(ctx, to, ev)
+}
+
+// ChatSession is an open Chat stream: the events of its user, and sending
+// on their behalf.
+type ChatSession struct {
+	Events <-chan shared.Packet[shared.Event]
 
+	chat    *ChatService
+	auth    shared.Session
+	expires time.Time
+	ended   <-chan struct{}
+}
+
+// Chat verifies auth and opens a chat session on it, whose events are those
+// of Events.
+func (s *ChatService) Chat(ctx context.Context, auth shared.Session, presence []uuid.UUID) (*ChatSession, error) {
+	events, expires, ended, err := s.openEvents(ctx, auth, presence)
+	if err != nil {
+		return nil, err
+	}
+	return &ChatSession{
+		Events:  events,
+		chat:    s,
+		auth:    auth,
+		expires: expires,
+		ended:   ended,
+	}, nil
+}
+
+// Send sends on behalf of the session user. The session was verified when
+// the stream opened, so only its expiry and end are checked here.
+func (c *ChatSession) Send(ctx context.Context, to string, str string, attachments []uuid.UUID, replyTo uuid.UUID) (shared.Message, error) {
 	select {
-	case ch <- shared.Message{
-		Sender:  auth.UserID,
-		Content: str,
-	}:
-		return nil
-	case <-ctx.Done():
-		return ctx.Err()
+	case <-c.ended:
+		return shared.Message{}, fmt.Errorf("session ended: %w", shared.ErrUnauthenticated)
+	default:
 	}
+	if time.Now().After(c.expires) {
+		return shared.Message{}, fmt.Errorf("session expired: %w", shared.ErrExpired)
+	}
+	return c.chat.deliver(ctx, c.auth.UserID, to, str, attachments, replyTo)
 }
 
-func (s *ChatService) Listen(ctx context.Context, auth shared.Session) (<-chan shared.Packet[shared.Message], error) {
-	err := s.user.VerifySession(ctx, auth)
+// LookupUser looks up the user named by ref for the session user, if any,
+// as UserService.LookupUser does, hiding users who blocked them.
+func (s *ChatService) LookupUser(ctx context.Context, auth *shared.Session, ref string) (shared.UserIdentity, error) {
+	user, err := s.user.LookupUser(ctx, auth, ref)
+	if err != nil || auth == nil {
+		return user, err
+	}
+	blocked, err := s.blocks.Exists(ctx, user.ID, auth.UserID)
+	if err != nil {
+		return shared.UserIdentity{}, fmt.Errorf("get block: %w", err)
+	}
+	if blocked {
+		return shared.UserIdentity{}, fmt.Errorf("get user: %w", shared.ErrNotExist)
+	}
+	return user, nil
+}
 
+// BlockUser blocks the user named by ref, either an ID or a name, for the
+// session user. Blocked users cannot reach them, see their presence or look
+// them up.
+func (s *ChatService) BlockUser(ctx context.Context, auth shared.Session, ref string) (shared.Block, error) {
+	if err := s.user.VerifySession(ctx, auth); err != nil {
+		return shared.Block{}, fmt.Errorf("verify session: %w", err)
+	}
+	user, err := s.resolve(ctx, ref)
 	if err != nil {
+		return shared.Block{}, fmt.Errorf("get user: %w", err)
+	}
+	if user.ID == auth.UserID {
+		return shared.Block{}, shared.NewError(shared.ErrInvalid, "cannot block yourself")
+	}
+
+	b := server.BlockRecord{
+		Blocker:   auth.UserID,
+		Blocked:   user.ID,
+		CreatedAt: time.Now(),
+	}
+	if err := s.blocks.Add(ctx, b); err != nil {
+		return shared.Block{}, fmt.Errorf("add block: %w", err)
+	}
+	s.notifyPresence(ctx, auth.UserID)
+	return shared.Block{UserID: user.ID, BlockedAt: b.CreatedAt}, nil
+}
+
+func (s *ChatService) UnblockUser(ctx context.Context, auth shared.Session, id uuid.UUID) error {
+	if err := s.user.VerifySession(ctx, auth); err != nil {
+		return fmt.Errorf("verify session: %w", err)
+	}
+	if err := s.blocks.Remove(ctx, auth.UserID, id); err != nil {
+		return fmt.Errorf("remove block: %w", err)
+	}
+	s.notifyPresence(ctx, auth.UserID)
+	return nil
+}
+
+func (s *ChatService) ListBlocks(ctx context.Context, auth shared.Session) ([]shared.Block, error) {
+	if err := s.user.VerifySession(ctx, auth); err != nil {
 		return nil, fmt.Errorf("verify session: %w", err)
 	}
+	bs, err := s.blocks.List(ctx, auth.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("list blocks: %w", err)
+	}
+	list := make([]shared.Block, 0, len(bs))
+	for _, b := range bs {
+		list = append(list, shared.Block{UserID: b.Blocked, BlockedAt: b.CreatedAt})
+	}
+	return list, nil
+}
+
+// Broadcast sends a notice with content from the server to the users
+// matching f, and returns it with the number of recipients. Online users are
+// sent it at once, the others when they next open their event stream.
+func (s *ChatService) Broadcast(ctx context.Context, content string, f server.BroadcastFilter) (shared.Notice, int, error) {
+	if content == "" {
+		return shared.Notice{}, 0, shared.NewError(shared.ErrInvalid, "empty notice")
+	}
+	recipients, err := s.broadcastRecipients(ctx, f)
+	if err != nil {
+		return shared.Notice{}, 0, err
+	}
+
+	n := shared.Notice{
+		ID:      uuid.New(),
+		Content: content,
+		SentAt:  time.Now(),
+	}
+	err = s.user.txRunner.Exec(ctx, func(ctx context.Context) error {
+		return s.notices.Save(ctx, n, recipients)
+	})
+	if err != nil {
+		return shared.Notice{}, 0, fmt.Errorf("save notice: %w", err)
+	}
+
+	// A notice stays pending until a stream has sent it, so one that does
+	// not fit in a full inbox is sent by the next stream instead.
+	for _, id := range recipients {
+		if s.online.Online(id) {
+			_ = s.publish(ctx, id, shared.Event{Notice: &n})
+		}
+	}
+	return n, len(recipients), nil
+}
+
+func (s *ChatService) broadcastRecipients(ctx context.Context, f server.BroadcastFilter) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if len(f.UserIDs) > 0 {
+		seen := make(map[uuid.UUID]bool, len(f.UserIDs))
+		for _, id := range f.UserIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			user, err := s.users.GetByID(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("get user %s: %w", id, err)
+			}
+			if len(f.States) == 0 || slices.Contains(f.States, user.State) {
+				ids = append(ids, id)
+			}
+		}
+		return ids, nil
+	}
+
+	q := server.UserListQuery{Limit: 500}
+	for {
+		list, err := s.users.List(ctx, q)
+		if err != nil {
+			return nil, fmt.Errorf("list users: %w", err)
+		}
+		for _, user := range list.Users {
+			if len(f.States) == 0 || slices.Contains(f.States, user.State) {
+				ids = append(ids, user.ID)
+			}
+		}
+		if list.Cursor == uuid.Nil {
+			return ids, nil
+		}
+		q.Cursor = list.Cursor
+	}
+}
+
+// ClaimNotice marks notice id as sent to user, so that later streams do not
+// send it again. Stream handlers call it once they have sent the notice.
+func (s *ChatService) ClaimNotice(ctx context.Context, user, id uuid.UUID) error {
+	if _, err := s.notices.Claim(ctx, user, id); err != nil {
+		return fmt.Errorf("claim notice: %w", err)
+	}
+	return nil
+}
+
+// resolve resolves ref, either an ID or a name, to a user. Names are
+// refused while the directory is closed.
+func (s *ChatService) resolve(ctx context.Context, ref string) (server.User, error) {
+	if _, err := uuid.Parse(ref); err != nil && s.user.directory == server.DirectoryClosed {
+		return server.User{}, shared.NewError(shared.ErrPermissionDenied, "user directory is closed, address users by id")
+	}
+	return s.user.ResolveUser(ctx, ref)
+}
+
+// checkBlocked applies the block policy to something from sent to user,
+// reporting whether to drop it.
+func (s *ChatService) checkBlocked(ctx context.Context, user, from uuid.UUID) (bool, error) {
+	blocked, err := s.blocks.Exists(ctx, user, from)
+	if err != nil {
+		return false, fmt.Errorf("get block: %w", err)
+	}
+	if !blocked {
+		return false, nil
+	}
+	if s.blockPolicy == server.BlockReject {
+		return false, shared.NewError(shared.ErrPermissionDenied, "recipient has blocked you")
+	}
+	return true, nil
+}
+
+// Events streams the events of the session user: received messages and
+// changes in the presence of the users in presence, preceded by their
+// current state and the notices not yet sent to the user. Notices stay
+// pending until the caller passes them to ClaimNotice. The user is online
+// while the stream is open.
+func (s *ChatService) Events(ctx context.Context, auth shared.Session, presence []uuid.UUID) (<-chan shared.Packet[shared.Event], error) {
+	events, _, _, err := s.openEvents(ctx, auth, presence)
+	return events, err
+}
+
+// openEvents opens the events of Events, and returns when the session expires
+// and a channel closed once it ends.
+func (s *ChatService) openEvents(ctx context.Context, auth shared.Session, presence []uuid.UUID) (<-chan shared.Packet[shared.Event], time.Time, <-chan struct{}, error) {
+	// Watched before verifying, so that a logout meanwhile is not missed.
+	ended, unwatch := s.user.watchSession(auth)
+	expires, err := s.user.verifySession(ctx, auth)
+	if err != nil {
+		unwatch()
+		return nil, time.Time{}, nil, fmt.Errorf("verify session: %w", err)
+	}
+	ln, err := s.listen(ctx, auth, presence, expires, ended, unwatch)
+	if err != nil {
+		unwatch()
+		return nil, time.Time{}, nil, err
+	}
+	return ln, expires, ended, nil
+}
 
-	ch, err := s.msgs.Get(ctx, auth.UserID)
+// listen streams the events of the session user until ctx is done or the
+// session expires or ends, and calls unwatch once it stops.
+func (s *ChatService) listen(
+	ctx context.Context,
+	auth shared.Session,
+	presence []uuid.UUID,
+	expires time.Time,
+	ended <-chan struct{},
+	unwatch func(),
+) (<-chan shared.Packet[shared.Event], error) {
+	ch, err := s.events.Get(ctx, auth.UserID)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.events.Refill(ctx, auth.UserID); err != nil {
+		return nil, err
+	}
 
-	ln := make(chan shared.Packet[shared.Message])
+	var watch <-chan shared.Presence
+	var initial []shared.Presence
+	stop := func() {}
+	if len(presence) > 0 {
+		watch, stop = s.online.Watch(presence)
+		initial, err = s.Presence(ctx, auth, presence)
+		if err != nil {
+			stop()
+			return nil, err
+		}
+	}
+
+	if s.online.Connect(auth.UserID) {
+		s.notifyPresence(ctx, auth.UserID)
+	}
+	// Listed once online, so that a broadcast meanwhile is either listed
+	// or queued, if not both.
+	notices, err := s.notices.ListPending(ctx, auth.UserID)
+	if err != nil {
+		stop()
+		s.disconnect(context.WithoutCancel(ctx), auth.UserID)
+		return nil, fmt.Errorf("list pending notices: %w", err)
+	}
+	sent := make(map[uuid.UUID]bool, len(notices))
+
+	ln := make(chan shared.Packet[shared.Event])
 
 	go func() {
 		defer close(ln)
+		defer unwatch()
+		defer stop()
+		defer s.disconnect(context.WithoutCancel(ctx), auth.UserID)
+		expiry := time.NewTimer(time.Until(expires))
+		defer expiry.Stop()
+		emit := func(ev shared.Event) bool {
+			select {
+			case ln <- shared.Packet[shared.Event]{Msg: ev}:
+				return true
+			case <-ctx.Done():
+				closePacket(ctx, ln, context.Cause(ctx))
+				return false
+			}
+		}
+		for _, p := range initial {
+			if !emit(shared.Event{Presence: &p}) {
+				return
+			}
+		}
+		for _, n := range notices {
+			sent[n.ID] = true
+			if !emit(shared.Event{Notice: &n}) {
+				return
+			}
+		}
 		for {
 			select {
 			case <-ctx.Done():
-				ln <- shared.Packet[shared.Message]{Err: context.Cause(ctx)}
+				closePacket(ctx, ln, context.Cause(ctx))
+				return
+			case <-ended:
+				closePacket(ctx, ln, fmt.Errorf("session ended: %w", shared.ErrUnauthenticated))
+				return
+			case <-expiry.C:
+				closePacket(ctx, ln, fmt.Errorf("session expired: %w", shared.ErrExpired))
 				return
-			case msg, ok := <-ch:
+			case ev, ok := <-ch:
 				if !ok {
 					return
 				}
+				// A notice both listed and queued is only sent once.
+				if ev.Notice == nil || !sent[ev.Notice.ID] {
+					if !emit(ev) {
+						return
+					}
+				}
+				if err := s.events.Refill(ctx, auth.UserID); err != nil {
+					closePacket(ctx, ln, err)
+					return
+				}
+			case p := <-watch:
+				p = s.presenceFor(ctx, p, auth.UserID)
+				if !emit(shared.Event{Presence: &p}) {
+					return
+				}
+			}
+		}
+	}()
+
+	return ln, nil
+}
+
+// Listen streams the messages received by the session user. It predates
+// Events and drops every other kind of event.
+func (s *ChatService) Listen(ctx context.Context, auth shared.Session) (<-chan shared.Packet[shared.Message], error) {
+	events, err := s.Events(ctx, auth, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ln := make(chan shared.Packet[shared.Message])
+
+	go func() {
+		defer close(ln)
+		for pck := range events {
+			if pck.Err != nil {
+				closePacket(ctx, ln, pck.Err)
+				return
+			}
+			if pck.Msg.Message == nil {
+				continue
+			}
+			select {
+			case ln <- shared.Packet[shared.Message]{Msg: *pck.Msg.Message}:
+			case <-ctx.Done():
+				closePacket(ctx, ln, context.Cause(ctx))
+				return
+			}
+		}
+	}()
+
+	return ln, nil
+}
+
+// Presence returns the presence of ids as visible to the session user.
+func (s *ChatService) Presence(ctx context.Context, auth shared.Session, ids []uuid.UUID) ([]shared.Presence, error) {
+	if err := s.user.VerifySession(ctx, auth); err != nil {
+		return nil, fmt.Errorf("verify session: %w", err)
+	}
+
+	ps := make([]shared.Presence, 0, len(ids))
+	for _, id := range ids {
+		p, err := s.visiblePresence(ctx, id, auth.UserID)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+	return ps, nil
+}
+
+// WatchPresence streams the presence of ids, starting with their current
+// state and followed by every change.
+func (s *ChatService) WatchPresence(ctx context.Context, auth shared.Session, ids []uuid.UUID) (<-chan shared.Packet[shared.Presence], error) {
+	ch, stop := s.online.Watch(ids)
+	initial, err := s.Presence(ctx, auth, ids)
+	if err != nil {
+		stop()
+		return nil, err
+	}
+
+	ln := make(chan shared.Packet[shared.Presence])
+
+	go func() {
+		defer close(ln)
+		defer stop()
+		for _, p := range initial {
+			select {
+			case ln <- shared.Packet[shared.Presence]{Msg: p}:
+			case <-ctx.Done():
+				closePacket(ctx, ln, context.Cause(ctx))
+				return
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				closePacket(ctx, ln, context.Cause(ctx))
+				return
+			case p := <-ch:
+				p = s.presenceFor(ctx, p, auth.UserID)
 				select {
-				case ln <- shared.Packet[shared.Message]{Msg: msg}:
+				case ln <- shared.Packet[shared.Presence]{Msg: p}:
 				case <-ctx.Done():
-					ln <- shared.Packet[shared.Message]{Err: context.Cause(ctx)}
+					closePacket(ctx, ln, context.Cause(ctx))
 					return
 				}
 			}
@@ -122,3 +864,83 @@ func (s *ChatService) Listen(ctx context.Context, auth shared.Session) (<-chan s
 
 	return ln, nil
 }
+
+// SetPresenceHidden hides the presence of the session user from others,
+// who then see them as offline with no last-seen time.
+func (s *ChatService) SetPresenceHidden(ctx context.Context, auth shared.Session, hidden bool) error {
+	if err := s.user.VerifySession(ctx, auth); err != nil {
+		return fmt.Errorf("verify session: %w", err)
+	}
+	if err := s.presence.UpdateHidden(ctx, auth.UserID, hidden); err != nil {
+		return fmt.Errorf("update presence: %w", err)
+	}
+	s.notifyPresence(ctx, auth.UserID)
+	return nil
+}
+
+func (s *ChatService) disconnect(ctx context.Context, id uuid.UUID) {
+	if !s.online.Disconnect(id) {
+		return
+	}
+	// Best effort, the stream is already gone.
+	_ = s.presence.UpdateLastSeen(ctx, id, time.Now())
+	s.notifyPresence(ctx, id)
+}
+
+func (s *ChatService) notifyPresence(ctx context.Context, id uuid.UUID) {
+	p, err := s.visiblePresence(ctx, id, uuid.Nil)
+	if err != nil {
+		return
+	}
+	s.online.Notify(p)
+}
+
+// visiblePresence returns the presence of id as seen by viewer, who always
+// sees their own. A zero viewer sees it as anyone not blocked does.
+func (s *ChatService) visiblePresence(ctx context.Context, id, viewer uuid.UUID) (shared.Presence, error) {
+	p := shared.Presence{UserID: id}
+	rec, err := s.presence.Get(ctx, id)
+	if err != nil && !errors.Is(err, shared.ErrNotExist) {
+		return p, fmt.Errorf("get presence: %w", err)
+	}
+	if rec.Hidden && id != viewer {
+		return p, nil
+	}
+	if viewer != uuid.Nil && id != viewer {
+		blocked, err := s.blocks.Exists(ctx, id, viewer)
+		if err != nil {
+			return p, fmt.Errorf("get block: %w", err)
+		}
+		if blocked {
+			return p, nil
+		}
+	}
+	p.Online = s.online.Online(id)
+	if !p.Online {
+		p.LastSeen = rec.LastSeen
+	}
+	return p, nil
+}
+
+// presenceFor hides a presence change from a viewer the user blocked, who
+// sees them offline with no last-seen time.
+func (s *ChatService) presenceFor(ctx context.Context, p shared.Presence, viewer uuid.UUID) shared.Presence {
+	if p.UserID == viewer {
+		return p
+	}
+	blocked, err := s.blocks.Exists(ctx, p.UserID, viewer)
+	if err != nil || blocked {
+		return shared.Presence{UserID: p.UserID}
+	}
+	return p
+}
+
+// closePacket hands err to the reader of ln, unless ctx is done first.
+// Readers watch the same context, so one that has gone does not block the
+// sender.
+func closePacket[T any](ctx context.Context, ln chan<- shared.Packet[T], err error) {
+	select {
+	case ln <- shared.Packet[T]{Err: err}:
+	case <-ctx.Done():
+	}
+}
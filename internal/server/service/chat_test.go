@@ -0,0 +1,288 @@
+package service_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+	"github.com/uptrace/bun/driver/sqliteshim"
+
+	server "github.com/charadev96/gonec/internal/server/domain"
+	"github.com/charadev96/gonec/internal/server/repo"
+	"github.com/charadev96/gonec/internal/server/service"
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+	"github.com/charadev96/gonec/internal/shared/infra"
+)
+
+type chatFixture struct {
+	db          *bun.DB
+	chat        *service.ChatService
+	user        *service.UserService
+	attachments *service.AttachmentService
+
+	sessions *repo.BunSessionRepository
+	users    *repo.BunUserRepository
+}
+
+func newChatFixture(t *testing.T, opts ...service.EventBrokerOption) *chatFixture {
+	t.Helper()
+	ctx := context.Background()
+
+	sqldb, err := sql.Open(sqliteshim.ShimName, "file::memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqldb.SetMaxOpenConns(1)
+	db := bun.NewDB(sqldb, sqlitedialect.New())
+	t.Cleanup(func() { db.Close() })
+
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	users, err := repo.NewBunUserRepository(ctx, db)
+	must(err)
+	invites, err := repo.NewBunInviteCredentialRepository(ctx, db)
+	must(err)
+	nonces, err := repo.NewBunLoginNonceRepository(ctx, db)
+	must(err)
+	sessions, err := repo.NewBunSessionRepository(ctx, db)
+	must(err)
+	presence, err := repo.NewBunPresenceRepository(ctx, db)
+	must(err)
+	messages, err := repo.NewBunMessageRepository(ctx, db)
+	must(err)
+	reactions, err := repo.NewBunReactionRepository(ctx, db)
+	must(err)
+	blocks, err := repo.NewBunBlockRepository(ctx, db)
+	must(err)
+	notices, err := repo.NewBunNoticeRepository(ctx, db)
+	must(err)
+	attachments, err := repo.NewBunAttachmentRepository(ctx, db)
+	must(err)
+	blobs, err := repo.NewFileBlobStore(t.TempDir())
+	must(err)
+
+	pub, _, err := ed25519.GenerateKey(nil)
+	must(err)
+	id := shared.ServerIdentity{IPAddress: "127.0.0.1:1", PublicKey: pub}
+	us := service.NewUserService(id, users, invites, nonces, sessions, infra.NewBunTransactionRunner(db))
+	as := service.NewAttachmentService(attachments, blobs, us)
+
+	b := service.NewEventBroker(opts...)
+	return &chatFixture{
+		db:          db,
+		chat:        service.NewChatService(users, presence, messages, reactions, blocks, notices, us, as, service.ChatWithEventBroker(b)),
+		user:        us,
+		attachments: as,
+		sessions:    sessions,
+		users:       users,
+	}
+}
+
+func (f *chatFixture) login(t *testing.T) shared.Session {
+	t.Helper()
+	ctx := context.Background()
+	uid, err := f.users.Create(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := shared.Session{ID: uuid.New(), UserID: uid, Token: []byte(uid.String())}
+	if err := f.sessions.Save(ctx, server.Session{Session: s, CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func (f *chatFixture) count(t *testing.T, table string) int {
+	t.Helper()
+	n, err := f.db.NewSelect().Table(table).Count(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestSendToFullInboxStoresNothing(t *testing.T) {
+	ctx := context.Background()
+	f := newChatFixture(t, service.BrokerWithInboxSize(1), service.BrokerWithOverflowPolicy(server.OverflowReject))
+	alice, bob := f.login(t), f.login(t)
+
+	msg, err := f.chat.Send(ctx, alice, bob.UserID.String(), "first", nil, uuid.Nil)
+	if err != nil {
+		t.Fatalf("send to empty inbox: %v", err)
+	}
+	if n := f.count(t, "messages"); n != 1 {
+		t.Fatalf("stored %d messages after first send, want 1", n)
+	}
+
+	for range 2 {
+		_, err = f.chat.Send(ctx, alice, bob.UserID.String(), "second", nil, uuid.Nil)
+		if !errors.Is(err, shared.ErrResourceExhausted) {
+			t.Fatalf("send to full inbox: got %v, want ErrResourceExhausted", err)
+		}
+	}
+	if n := f.count(t, "messages"); n != 1 {
+		t.Fatalf("stored %d messages after rejected sends, want 1", n)
+	}
+
+	_, err = f.chat.EditMessage(ctx, alice, msg.ID, "edited")
+	if !errors.Is(err, shared.ErrResourceExhausted) {
+		t.Fatalf("edit with full inbox: got %v, want ErrResourceExhausted", err)
+	}
+	if n := f.count(t, "message_revisions"); n != 0 {
+		t.Fatalf("stored %d revisions after rejected edit, want 0", n)
+	}
+}
+
+func TestChatSendAfterLogout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	f := newChatFixture(t)
+	alice, bob := f.login(t), f.login(t)
+
+	chat, err := f.chat.Chat(ctx, alice, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chat.Send(ctx, bob.UserID.String(), "hi", nil, uuid.Nil); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	if err := f.user.LogoutUser(ctx, alice); err != nil {
+		t.Fatal(err)
+	}
+	_, err = chat.Send(ctx, bob.UserID.String(), "still there?", nil, uuid.Nil)
+	if !errors.Is(err, shared.ErrUnauthenticated) {
+		t.Fatalf("send after logout: got %v, want ErrUnauthenticated", err)
+	}
+	if n := f.count(t, "messages"); n != 1 {
+		t.Fatalf("stored %d messages, want 1", n)
+	}
+
+	// The stream ends with the cause, even with its reader busy meanwhile.
+	time.Sleep(10 * time.Millisecond)
+	var last error
+	for pck := range chat.Events {
+		last = pck.Err
+	}
+	if !errors.Is(last, shared.ErrUnauthenticated) {
+		t.Fatalf("events ended with %v, want ErrUnauthenticated", last)
+	}
+}
+
+func TestListThreadShowsRevisionsAndReactions(t *testing.T) {
+	ctx := context.Background()
+	f := newChatFixture(t)
+	alice, bob := f.login(t), f.login(t)
+
+	root, err := f.chat.Send(ctx, alice, bob.UserID.String(), "root", nil, uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited, err := f.chat.Send(ctx, bob, alice.UserID.String(), "first reply", nil, root.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleted, err := f.chat.Send(ctx, bob, alice.UserID.String(), "second reply", nil, root.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.chat.EditMessage(ctx, bob, edited.ID, "first reply, fixed"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.chat.DeleteMessage(ctx, bob, deleted.ID); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []struct {
+		auth  shared.Session
+		emoji string
+	}{{alice, "👍"}, {bob, "👍"}, {alice, "🎉"}} {
+		if _, err := f.chat.React(ctx, r.auth, edited.ID, r.emoji, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	page, err := f.chat.ListThread(ctx, alice, root.ID, uuid.Nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Messages) != 2 {
+		t.Fatalf("listed %d replies, want 2", len(page.Messages))
+	}
+	first, second := page.Messages[0], page.Messages[1]
+	if first.Content != "first reply, fixed" || first.EditedAt.IsZero() {
+		t.Errorf("edited reply: content %q, edited at %v", first.Content, first.EditedAt)
+	}
+	want := []shared.ReactionCount{{Emoji: "👍", Count: 2}, {Emoji: "🎉", Count: 1}}
+	if len(first.Reactions) != len(want) {
+		t.Fatalf("reactions %v, want %v", first.Reactions, want)
+	}
+	for i := range want {
+		if first.Reactions[i] != want[i] {
+			t.Errorf("reactions %v, want %v", first.Reactions, want)
+		}
+	}
+	if second.DeletedAt.IsZero() || second.Content != "" {
+		t.Errorf("deleted reply: content %q, deleted at %v", second.Content, second.DeletedAt)
+	}
+}
+
+func TestChatStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	f := newChatFixture(t)
+	alice, bob := f.login(t), f.login(t)
+
+	chat, err := f.chat.Chat(ctx, alice, []uuid.UUID{bob.UserID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := func() shared.Event {
+		t.Helper()
+		select {
+		case pck, ok := <-chat.Events:
+			if !ok || pck.Err != nil {
+				t.Fatalf("events ended: %v", pck.Err)
+			}
+			return pck.Msg
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event")
+		}
+		return shared.Event{}
+	}
+
+	if ev := next(); ev.Presence == nil || ev.Presence.UserID != bob.UserID {
+		t.Fatalf("first event %+v, want the presence of bob", ev)
+	}
+	sent, err := f.chat.Send(ctx, bob, alice.UserID.String(), "hi alice", nil, uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev := next(); ev.Message == nil || ev.Message.ID != sent.ID {
+		t.Fatalf("got event %+v, want the message of bob", ev)
+	}
+
+	reply, err := chat.Send(ctx, bob.UserID.String(), "hi bob", nil, sent.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Sender != alice.UserID || reply.ThreadID != sent.ID {
+		t.Fatalf("reply sent as %v in thread %v", reply.Sender, reply.ThreadID)
+	}
+
+	cancel()
+	for pck := range chat.Events {
+		if pck.Err != nil && !errors.Is(pck.Err, context.Canceled) {
+			t.Fatalf("events ended with %v, want the cancellation", pck.Err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+)
+
+var presenceQueueSize = 32
+
+// PresenceTracker counts the open Listen streams of each user, who is online
+// while there is at least one, and fans presence changes out to watchers.
+type PresenceTracker struct {
+	mu       sync.Mutex
+	streams  map[uuid.UUID]int
+	watchers map[uuid.UUID]map[chan shared.Presence]struct{}
+}
+
+func NewPresenceTracker() *PresenceTracker {
+	return &PresenceTracker{
+		streams:  make(map[uuid.UUID]int),
+		watchers: make(map[uuid.UUID]map[chan shared.Presence]struct{}),
+	}
+}
+
+// Connect records an opened stream and reports whether the user came online.
+func (t *PresenceTracker) Connect(id uuid.UUID) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.streams[id]++
+	return t.streams[id] == 1
+}
+
+// Disconnect records a closed stream and reports whether the user went
+// offline.
+func (t *PresenceTracker) Disconnect(id uuid.UUID) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.streams[id]--
+	if t.streams[id] > 0 {
+		return false
+	}
+	delete(t.streams, id)
+	return true
+}
+
+func (t *PresenceTracker) Online(id uuid.UUID) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.streams[id] > 0
+}
+
+// Watch subscribes to presence changes of ids until the returned function is
+// called.
+func (t *PresenceTracker) Watch(ids []uuid.UUID) (<-chan shared.Presence, func()) {
+	ch := make(chan shared.Presence, presenceQueueSize)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, id := range ids {
+		if t.watchers[id] == nil {
+			t.watchers[id] = make(map[chan shared.Presence]struct{})
+		}
+		t.watchers[id][ch] = struct{}{}
+	}
+
+	return ch, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		for _, id := range ids {
+			delete(t.watchers[id], ch)
+			if len(t.watchers[id]) == 0 {
+				delete(t.watchers, id)
+			}
+		}
+	}
+}
+
+// Notify sends p to the watchers of its user. Watchers too slow to keep up
+// miss the change rather than hold up the others.
+func (t *PresenceTracker) Notify(p shared.Presence) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for ch := range t.watchers[p.UserID] {
+		select {
+		case ch <- p:
+		default:
+		}
+	}
+}
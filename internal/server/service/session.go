@@ -0,0 +1,60 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+)
+
+// sessionWatch tells open streams that their session ended, so that they
+// need not read it back on every request.
+type sessionWatch struct {
+	mu       sync.Mutex
+	watchers map[uuid.UUID]map[chan struct{}]uuid.UUID
+}
+
+func newSessionWatch() *sessionWatch {
+	return &sessionWatch{
+		watchers: make(map[uuid.UUID]map[chan struct{}]uuid.UUID),
+	}
+}
+
+// watch returns a channel closed once sess ends, until the returned
+// function is called.
+func (w *sessionWatch) watch(sess shared.Session) (<-chan struct{}, func()) {
+	ch := make(chan struct{})
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.watchers[sess.UserID] == nil {
+		w.watchers[sess.UserID] = make(map[chan struct{}]uuid.UUID)
+	}
+	w.watchers[sess.UserID][ch] = sess.ID
+
+	return ch, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.watchers[sess.UserID], ch)
+		if len(w.watchers[sess.UserID]) == 0 {
+			delete(w.watchers, sess.UserID)
+		}
+	}
+}
+
+// end closes the watches of the session id of user, or of all their
+// sessions if id is zero.
+func (w *sessionWatch) end(user, id uuid.UUID) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for ch, sid := range w.watchers[user] {
+		if id == uuid.Nil || sid == id {
+			close(ch)
+			delete(w.watchers[user], ch)
+		}
+	}
+	if len(w.watchers[user]) == 0 {
+		delete(w.watchers, user)
+	}
+}
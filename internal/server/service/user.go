@@ -14,8 +14,11 @@ import (
 
 	server "github.com/charadev96/gonec/internal/server/domain"
 	shared "github.com/charadev96/gonec/internal/shared/domain"
+	"github.com/charadev96/gonec/internal/shared/log"
 )
 
+const sessionLifetime = 12 * time.Hour
+
 type UserService struct {
 	users    server.UserRepository
 	invites  server.InviteCredentialRepository
@@ -23,9 +26,12 @@ type UserService struct {
 	sessions server.SessionRepository
 	txRunner shared.TransactionRunner
 
-	server shared.ServerIdentity
+	server    shared.ServerIdentity
+	rollover  *shared.KeyRollover
+	directory server.DirectoryPolicy
 
-	rand io.Reader
+	rand    io.Reader
+	watches *sessionWatch
 }
 
 type UserServiceOption func(*UserService)
@@ -36,6 +42,18 @@ func UserWithRand(r io.Reader) UserServiceOption {
 	}
 }
 
+func UserWithKeyRollover(r shared.KeyRollover) UserServiceOption {
+	return func(s *UserService) {
+		s.rollover = &r
+	}
+}
+
+func UserWithDirectoryPolicy(p server.DirectoryPolicy) UserServiceOption {
+	return func(s *UserService) {
+		s.directory = p
+	}
+}
+
 func NewUserService(
 	id shared.ServerIdentity,
 	usr server.UserRepository,
@@ -54,7 +72,8 @@ func NewUserService(
 
 		server: id,
 
-		rand: rand.Reader,
+		rand:    rand.Reader,
+		watches: newSessionWatch(),
 	}
 	for _, opt := range opts {
 		opt(s)
@@ -70,6 +89,13 @@ func (s *UserService) Invites() server.InviteCredentialRepository {
 	return s.invites
 }
 
+func (s *UserService) KeyRollover() (shared.KeyRollover, error) {
+	if s.rollover == nil {
+		return shared.KeyRollover{}, fmt.Errorf("key rollover: %w", shared.ErrNotExist)
+	}
+	return *s.rollover, nil
+}
+
 type CreateInviteOptions struct {
 	NotBefore time.Time
 	NotAfter  time.Time
@@ -95,7 +121,7 @@ func (s *UserService) CreateInvite(ctx context.Context, id uuid.UUID, opts Creat
 		opts.NotAfter = time.Now().AddDate(0, 0, 1)
 	}
 	if opts.NotAfter.Before(opts.NotBefore) {
-		return inv, fmt.Errorf("bad time period, NotAfter must be after NotBefore")
+		return inv, fmt.Errorf("bad time period, NotAfter must be after NotBefore: %w", shared.ErrInvalid)
 	}
 
 	inv = shared.InviteCredential{
@@ -119,7 +145,7 @@ func (s *UserService) CreateLoginNonce(ctx context.Context, id uuid.UUID) ([]byt
 		return nil, fmt.Errorf("get user: %w", err)
 	}
 	if user.State == server.StatePending {
-		return nil, fmt.Errorf("user not registered")
+		return nil, fmt.Errorf("user not registered: %w", shared.ErrFailedPrecondition)
 	}
 
 	tok := make([]byte, 32)
@@ -167,22 +193,24 @@ func (s *UserService) RegisterUser(ctx context.Context, id uuid.UUID, tok []byte
 	}
 
 	if subtle.ConstantTimeCompare(inv.Token, tok) == 0 {
-		return fmt.Errorf("token mismatch")
+		return fmt.Errorf("token mismatch: %w", shared.ErrUnauthenticated)
 	}
 
 	now := time.Now()
 	if now.Before(inv.NotBefore) {
 		return fmt.Errorf(
-			"invitation not yet valid, current time %s is before %s",
+			"invitation not yet valid, current time %s is before %s: %w",
 			now.Format(time.RFC3339),
 			inv.NotBefore.Format(time.RFC3339),
+			shared.ErrFailedPrecondition,
 		)
 	}
 	if now.After(inv.NotAfter) {
 		return fmt.Errorf(
-			"invitation expired, current time %s is after %s",
+			"invitation expired, current time %s is after %s: %w",
 			now.Format(time.RFC3339),
 			inv.NotAfter.Format(time.RFC3339),
+			shared.ErrExpired,
 		)
 	}
 
@@ -201,22 +229,39 @@ func (s *UserService) RegisterUser(ctx context.Context, id uuid.UUID, tok []byte
 }
 
 func (s *UserService) VerifySession(ctx context.Context, sess shared.Session) error {
+	_, err := s.verifySession(ctx, sess)
+	return err
+}
+
+// verifySession is VerifySession, returning when the session expires.
+func (s *UserService) verifySession(ctx context.Context, sess shared.Session) (time.Time, error) {
 	session, err := s.sessions.GetByID(ctx, sess.ID)
 	if err != nil {
-		return fmt.Errorf("get session: %w", err)
+		if errors.Is(err, shared.ErrNotExist) {
+			err = shared.ErrUnauthenticated
+		}
+		return time.Time{}, fmt.Errorf("get session: %w", err)
 	}
 
 	if session.UserID != sess.UserID {
-		return fmt.Errorf("user id mismatch")
+		return time.Time{}, fmt.Errorf("user id mismatch: %w", shared.ErrUnauthenticated)
 	}
 	if subtle.ConstantTimeCompare(session.Token, sess.Token) == 0 {
-		return fmt.Errorf("token mismatch")
+		return time.Time{}, fmt.Errorf("token mismatch: %w", shared.ErrUnauthenticated)
 	}
-	if expired := time.Now().After(session.CreatedAt.Add(time.Hour * 12)); expired {
-		return fmt.Errorf("session expired")
+	expires := session.CreatedAt.Add(sessionLifetime)
+	if time.Now().After(expires) {
+		return time.Time{}, fmt.Errorf("session expired: %w", shared.ErrExpired)
 	}
 
-	return nil
+	log.SetUserID(ctx, sess.UserID.String())
+	return expires, nil
+}
+
+// watchSession returns a channel closed once sess is logged out or its
+// user deleted, until the returned function is called.
+func (s *UserService) watchSession(sess shared.Session) (<-chan struct{}, func()) {
+	return s.watches.watch(sess)
 }
 
 func (s *UserService) LoginUser(ctx context.Context, id uuid.UUID, sig []byte) (shared.Session, error) {
@@ -226,19 +271,19 @@ func (s *UserService) LoginUser(ctx context.Context, id uuid.UUID, sig []byte) (
 		return sess, fmt.Errorf("get user: %w", err)
 	}
 	if user.State == server.StatePending {
-		return sess, fmt.Errorf("user not registered")
+		return sess, fmt.Errorf("user not registered: %w", shared.ErrFailedPrecondition)
 	}
 
 	nonce, err := s.nonces.Consume(ctx, id)
 	if err != nil && errors.Is(err, shared.ErrNotExist) {
-		return sess, fmt.Errorf("consume nonce: %w", err)
+		return sess, fmt.Errorf("consume nonce: %w", shared.ErrUnauthenticated)
 	}
 
 	if expired := time.Now().After(nonce.CreatedAt.Add(time.Minute)); expired {
-		return sess, fmt.Errorf("challenge nonce expired")
+		return sess, fmt.Errorf("challenge nonce expired: %w", shared.ErrExpired)
 	}
 	if ok := ed25519.Verify(user.PublicKey, nonce.Value, sig); !ok {
-		return sess, fmt.Errorf("signature mismatch")
+		return sess, fmt.Errorf("signature mismatch: %w", shared.ErrUnauthenticated)
 	}
 
 	tok := make([]byte, 32)
@@ -261,6 +306,7 @@ func (s *UserService) LoginUser(ctx context.Context, id uuid.UUID, sig []byte) (
 		return sess, fmt.Errorf("save session: %w", err)
 	}
 
+	log.SetUserID(ctx, sess.UserID.String())
 	return sess, nil
 }
 
@@ -271,12 +317,13 @@ func (s *UserService) LogoutUser(ctx context.Context, sess shared.Session) error
 	if err := s.sessions.Delete(ctx, sess.ID); err != nil {
 		return fmt.Errorf("delete session: %w", err)
 	}
+	s.watches.end(sess.UserID, sess.ID)
 
 	return nil
 }
 
 func (s *UserService) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	return s.txRunner.Exec(ctx, func(ctx context.Context) error {
+	err := s.txRunner.Exec(ctx, func(ctx context.Context) error {
 		if err := s.users.Delete(ctx, id); err != nil {
 			return fmt.Errorf("delete user: %w", err)
 		}
@@ -288,4 +335,50 @@ func (s *UserService) DeleteUser(ctx context.Context, id uuid.UUID) error {
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	s.watches.end(id, uuid.Nil)
+	return nil
+}
+
+// ResolveUser finds a user by ID, or by name if ref is not one.
+func (s *UserService) ResolveUser(ctx context.Context, ref string) (server.User, error) {
+	if id, err := uuid.Parse(ref); err == nil {
+		return s.users.GetByID(ctx, id)
+	}
+	if ref == "" {
+		return server.User{}, fmt.Errorf("empty user reference: %w", shared.ErrInvalid)
+	}
+	return s.users.GetByName(ctx, ref)
+}
+
+// LookupUser returns the directory entry of a registered user as allowed by
+// the directory policy. auth may be nil when the directory is public.
+func (s *UserService) LookupUser(ctx context.Context, auth *shared.Session, ref string) (shared.UserIdentity, error) {
+	switch s.directory {
+	case server.DirectoryClosed:
+		return shared.UserIdentity{}, shared.NewError(shared.ErrPermissionDenied, "user directory is closed")
+	case server.DirectoryMembers:
+		if auth == nil {
+			return shared.UserIdentity{}, fmt.Errorf("missing session: %w", shared.ErrUnauthenticated)
+		}
+		if err := s.VerifySession(ctx, *auth); err != nil {
+			return shared.UserIdentity{}, fmt.Errorf("verify session: %w", err)
+		}
+	}
+
+	user, err := s.ResolveUser(ctx, ref)
+	if err != nil {
+		return shared.UserIdentity{}, fmt.Errorf("get user: %w", err)
+	}
+	if user.State == server.StatePending {
+		return shared.UserIdentity{}, fmt.Errorf("get user: %w", shared.ErrNotExist)
+	}
+
+	return shared.UserIdentity{
+		ID:        user.ID,
+		Name:      user.Name,
+		PublicKey: user.PublicKey,
+	}, nil
 }
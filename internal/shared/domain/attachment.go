@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+// Attachment is a blob a message refers to. Hash is the SHA-256 of its
+// contents.
+type Attachment struct {
+	ID       uuid.UUID
+	Size     int64
+	MIMEType string
+	Hash     []byte
+}
@@ -2,6 +2,10 @@ package domain
 
 import (
 	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,9 +13,19 @@ import (
 
 type ServerIdentity struct {
 	IPAddress string
+	Host      string
 	PublicKey ed25519.PublicKey
 }
 
+// Addr returns the address to dial, preferring Host over IPAddress. Both
+// are in host:port form, Host may name the server by DNS.
+func (s ServerIdentity) Addr() string {
+	if s.Host != "" {
+		return s.Host
+	}
+	return s.IPAddress
+}
+
 type InviteCredential struct {
 	UserID    uuid.UUID
 	Token     []byte
@@ -24,8 +38,79 @@ type InviteTicket struct {
 	Credential InviteCredential
 }
 
+// UserIdentity is the public part of a user, as listed in the directory.
+type UserIdentity struct {
+	ID        uuid.UUID
+	Name      string
+	PublicKey ed25519.PublicKey
+}
+
 type Session struct {
 	ID     uuid.UUID
 	UserID uuid.UUID
 	Token  []byte
 }
+
+func KeyFingerprint(pk ed25519.PublicKey) string {
+	sum := sha256.Sum256(pk)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+// KeyRollover is a statement by the holder of OldKey that the server
+// identity moves to NewKey within the validity window.
+type KeyRollover struct {
+	OldKey    ed25519.PublicKey
+	NewKey    ed25519.PublicKey
+	NotBefore time.Time
+	NotAfter  time.Time
+	Signature []byte
+}
+
+const keyRolloverContext = "gonec key rollover v1"
+
+func SignKeyRollover(old ed25519.PrivateKey, newKey ed25519.PublicKey, notBefore, notAfter time.Time) KeyRollover {
+	r := KeyRollover{
+		OldKey:    old.Public().(ed25519.PublicKey),
+		NewKey:    newKey,
+		NotBefore: notBefore,
+		NotAfter:  notAfter,
+	}
+	r.Signature = ed25519.Sign(old, r.signedBytes())
+	return r
+}
+
+func (r KeyRollover) Verify(now time.Time) error {
+	if len(r.OldKey) != ed25519.PublicKeySize || len(r.NewKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("bad rollover key length: %w", ErrInvalid)
+	}
+	if !ed25519.Verify(r.OldKey, r.signedBytes(), r.Signature) {
+		return fmt.Errorf("rollover signature mismatch: %w", ErrUnauthenticated)
+	}
+	if now.Before(r.NotBefore) {
+		return fmt.Errorf(
+			"rollover not yet valid, current time %s is before %s: %w",
+			now.Format(time.RFC3339),
+			r.NotBefore.Format(time.RFC3339),
+			ErrFailedPrecondition,
+		)
+	}
+	if now.After(r.NotAfter) {
+		return fmt.Errorf(
+			"rollover expired, current time %s is after %s: %w",
+			now.Format(time.RFC3339),
+			r.NotAfter.Format(time.RFC3339),
+			ErrExpired,
+		)
+	}
+	return nil
+}
+
+func (r KeyRollover) signedBytes() []byte {
+	b := make([]byte, 0, len(keyRolloverContext)+len(r.OldKey)+len(r.NewKey)+16)
+	b = append(b, keyRolloverContext...)
+	b = append(b, r.OldKey...)
+	b = append(b, r.NewKey...)
+	b = binary.BigEndian.AppendUint64(b, uint64(r.NotBefore.Unix()))
+	b = binary.BigEndian.AppendUint64(b, uint64(r.NotAfter.Unix()))
+	return b
+}
@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Block is a user blocked by the session user since BlockedAt.
+type Block struct {
+	UserID    uuid.UUID
+	BlockedAt time.Time
+}
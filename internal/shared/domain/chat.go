@@ -1,10 +1,65 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
+// Message is a chat message. ID and SentAt are assigned by the server on
+// delivery. A reply names the message it answers in ReplyTo, and the first
+// message of its thread in ThreadID. EditedAt, DeletedAt and Reactions are
+// only filled in when listing messages, events carry the changes instead.
 type Message struct {
-	Sender  uuid.UUID
-	Content string
+	ID        uuid.UUID
+	Sender    uuid.UUID
+	Recipient uuid.UUID
+	Content   string
+	SentAt    time.Time
+	EditedAt  time.Time
+	DeletedAt time.Time
+
+	Attachments []Attachment
+	Reactions   []ReactionCount
+
+	ReplyTo  uuid.UUID
+	ThreadID uuid.UUID
+}
+
+// ReactionCount is how many users put Emoji on a message.
+type ReactionCount struct {
+	Emoji string
+	Count int
+}
+
+// MessagePage is a page of messages, followed by more starting after
+// Cursor unless it is zero.
+type MessagePage struct {
+	Messages []Message
+	Cursor   uuid.UUID
+}
+
+// MessageEdit replaces the content of the message with ID, sent by Sender.
+type MessageEdit struct {
+	ID       uuid.UUID
+	Sender   uuid.UUID
+	Content  string
+	EditedAt time.Time
+}
+
+// MessageDelete retracts the message with ID, sent by Sender.
+type MessageDelete struct {
+	ID        uuid.UUID
+	Sender    uuid.UUID
+	DeletedAt time.Time
+}
+
+// Reaction is an emoji put on or, if Removed, taken off the message with
+// MessageID by UserID.
+type Reaction struct {
+	MessageID uuid.UUID
+	UserID    uuid.UUID
+	Emoji     string
+	Removed   bool
+	ReactedAt time.Time
 }
@@ -5,6 +5,30 @@ import (
 )
 
 var (
-	ErrNotExist = errors.New("resource does not exist")
-	ErrExist    = errors.New("resource already exists")
+	ErrNotExist           = errors.New("resource does not exist")
+	ErrExist              = errors.New("resource already exists")
+	ErrInvalid            = errors.New("invalid argument")
+	ErrUnauthenticated    = errors.New("unauthenticated")
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrExpired            = errors.New("credential expired")
+	ErrResourceExhausted  = errors.New("resource exhausted")
+	ErrFailedPrecondition = errors.New("failed precondition")
 )
+
+// Error is a domain error whose message is safe to show to clients.
+type Error struct {
+	Kind error
+	Msg  string
+}
+
+func NewError(kind error, msg string) *Error {
+	return &Error{Kind: kind, Msg: msg}
+}
+
+func (e *Error) Error() string {
+	return e.Msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
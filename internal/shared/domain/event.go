@@ -0,0 +1,12 @@
+package domain
+
+// Event is one update on the event stream of a user. Exactly one field is
+// set.
+type Event struct {
+	Message  *Message
+	Presence *Presence
+	Edit     *MessageEdit
+	Delete   *MessageDelete
+	Reaction *Reaction
+	Notice   *Notice
+}
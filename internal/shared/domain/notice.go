@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notice is a message from the server itself rather than from a user, such
+// as a maintenance announcement.
+type Notice struct {
+	ID      uuid.UUID
+	Content string
+	SentAt  time.Time
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Presence is the online status of a user as seen by others. LastSeen is
+// zero while online or if unknown.
+type Presence struct {
+	UserID   uuid.UUID
+	Online   bool
+	LastSeen time.Time
+}
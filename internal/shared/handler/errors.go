@@ -1,14 +1,144 @@
 package handler
 
 import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+	"github.com/charadev96/gonec/internal/shared/log"
 )
 
-func ErrInternal(err error) error {
-	return status.Error(codes.Internal, err.Error())
+const errorDomain = "gonec"
+
+var ErrShutdown = NewStatus(codes.Unavailable, "SHUTTING_DOWN", "server shutting down").Err()
+
+type errorKind struct {
+	target error
+	code   codes.Code
+	reason string
+}
+
+// Checked in order, the first match wins.
+var errorKinds = []errorKind{
+	{shared.ErrExpired, codes.Unauthenticated, "EXPIRED"},
+	{shared.ErrUnauthenticated, codes.Unauthenticated, "UNAUTHENTICATED"},
+	{shared.ErrPermissionDenied, codes.PermissionDenied, "PERMISSION_DENIED"},
+	{shared.ErrNotExist, codes.NotFound, "NOT_FOUND"},
+	{shared.ErrExist, codes.AlreadyExists, "ALREADY_EXISTS"},
+	{shared.ErrInvalid, codes.InvalidArgument, "INVALID_ARGUMENT"},
+	{shared.ErrResourceExhausted, codes.ResourceExhausted, "RESOURCE_EXHAUSTED"},
+	{shared.ErrFailedPrecondition, codes.FailedPrecondition, "FAILED_PRECONDITION"},
 }
 
 func ErrArg(err error) error {
-	return status.Error(codes.InvalidArgument, err.Error())
+	return NewStatus(codes.InvalidArgument, "INVALID_ARGUMENT", err.Error()).Err()
+}
+
+// NewStatus returns a status with a message safe to show, marked so that
+// Status passes it through.
+func NewStatus(c codes.Code, reason, msg string) *status.Status {
+	st := status.New(c, msg)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: errorDomain,
+	})
+	if err != nil {
+		return st
+	}
+	return detailed
+}
+
+// Reason returns the reason NewStatus or Status gave st, if any.
+func Reason(st *status.Status) (string, bool) {
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.Domain == errorDomain {
+			return info.Reason, true
+		}
+	}
+	return "", false
+}
+
+// Status translates err into a status safe to send to a client. Statuses
+// made by NewStatus or Status, here or by the server upstream, are passed
+// through as is, known domain errors are reduced to their sentinel or
+// public message and anything else, other statuses included, becomes an
+// opaque internal error.
+func Status(err error) *status.Status {
+	var se interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &se) {
+		if st := se.GRPCStatus(); st != nil {
+			if _, ok := Reason(st); ok {
+				return st
+			}
+			// Only the code of others is kept, if it says the call ended.
+			switch st.Code() {
+			case codes.Canceled:
+				return status.FromContextError(context.Canceled)
+			case codes.DeadlineExceeded:
+				return status.FromContextError(context.DeadlineExceeded)
+			}
+		}
+	}
+	// The sentinel alone, the wrapping may tell more than it should.
+	for _, target := range []error{context.Canceled, context.DeadlineExceeded} {
+		if errors.Is(err, target) {
+			return status.FromContextError(target)
+		}
+	}
+	for _, k := range errorKinds {
+		if !errors.Is(err, k.target) {
+			continue
+		}
+		msg := k.target.Error()
+		var de *shared.Error
+		if errors.As(err, &de) && errors.Is(de, k.target) {
+			msg = de.Msg
+		}
+		return NewStatus(k.code, k.reason, msg)
+	}
+	return NewStatus(codes.Internal, "INTERNAL", "internal error")
+}
+
+func UnaryErrorInterceptor(l zerolog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, translateError(ctx, l, info.FullMethod, err)
+		}
+		return resp, nil
+	}
+}
+
+func StreamErrorInterceptor(l zerolog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return translateError(ss.Context(), l, info.FullMethod, err)
+		}
+		return nil
+	}
+}
+
+func translateError(ctx context.Context, l zerolog.Logger, method string, err error) error {
+	l = log.Ctx(ctx, l)
+	st := Status(err)
+	var ev *zerolog.Event
+	switch st.Code() {
+	case codes.Internal, codes.Unknown:
+		ev = l.Error()
+	case codes.Canceled:
+		ev = l.Debug()
+	default:
+		ev = l.Warn()
+	}
+	ev.Err(err).
+		Str("method", method).
+		Str("code", st.Code().String()).
+		Msg("request failed")
+	return st.Err()
 }
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+)
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+		msg  string
+	}{
+		{
+			name: "sentinel",
+			err:  fmt.Errorf("get user: %w", shared.ErrNotExist),
+			code: codes.NotFound,
+			msg:  shared.ErrNotExist.Error(),
+		},
+		{
+			name: "public message",
+			err:  fmt.Errorf("send: %w", shared.NewError(shared.ErrResourceExhausted, "recipient inbox is full")),
+			code: codes.ResourceExhausted,
+			msg:  "recipient inbox is full",
+		},
+		{
+			name: "expired before unauthenticated",
+			err:  fmt.Errorf("verify session: %w", shared.ErrExpired),
+			code: codes.Unauthenticated,
+			msg:  shared.ErrExpired.Error(),
+		},
+		{
+			name: "own status",
+			err:  ErrArg(errors.New("bad id")),
+			code: codes.InvalidArgument,
+			msg:  "bad id",
+		},
+		{
+			name: "upstream domain status",
+			err:  fmt.Errorf("request login: %w", Status(shared.ErrUnauthenticated).Err()),
+			code: codes.Unauthenticated,
+			msg:  shared.ErrUnauthenticated.Error(),
+		},
+		{
+			name: "foreign status",
+			err:  fmt.Errorf("request login: %w", status.Error(codes.Unavailable, "dial tcp 10.0.0.1:443: connection refused")),
+			code: codes.Internal,
+			msg:  "internal error",
+		},
+		{
+			name: "foreign cancel",
+			err:  status.Error(codes.Canceled, "stream reset by peer 10.0.0.1"),
+			code: codes.Canceled,
+			msg:  context.Canceled.Error(),
+		},
+		{
+			name: "context",
+			err:  fmt.Errorf("list: %w", context.DeadlineExceeded),
+			code: codes.DeadlineExceeded,
+			msg:  context.DeadlineExceeded.Error(),
+		},
+		{
+			name: "unknown",
+			err:  errors.New("open /var/lib/gonec/db: permission denied"),
+			code: codes.Internal,
+			msg:  "internal error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := Status(tt.err)
+			if st.Code() != tt.code || st.Message() != tt.msg {
+				t.Fatalf("got %v %q, want %v %q", st.Code(), st.Message(), tt.code, tt.msg)
+			}
+			if tt.code == codes.Canceled || tt.code == codes.DeadlineExceeded {
+				return
+			}
+			if _, ok := Reason(st); !ok {
+				t.Fatalf("status %v carries no reason", st)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+var errPanic = NewStatus(codes.Internal, "INTERNAL", "internal error").Err()
+
+// UnaryRecoveryInterceptor turns a panic in a call into an internal error,
+// so that one bad request does not take the whole server down. It goes
+// first in the chain to cover the other interceptors too.
+func UnaryRecoveryInterceptor(l zerolog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logPanic(l, info.FullMethod, r)
+				err = errPanic
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func StreamRecoveryInterceptor(l zerolog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logPanic(l, info.FullMethod, r)
+				err = errPanic
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func logPanic(l zerolog.Logger, method string, r any) {
+	l.Error().
+		Interface("panic", r).
+		Str("method", method).
+		Bytes("stack", debug.Stack()).
+		Msg("request panicked")
+}
@@ -18,3 +18,11 @@ func ParseUUIDs(ids ...string) ([]uuid.UUID, error) {
 	}
 	return parsed, nil
 }
+
+// ParseOptionalUUID parses id, taking an empty one as the zero UUID.
+func ParseOptionalUUID(id string) (uuid.UUID, error) {
+	if id == "" {
+		return uuid.Nil, nil
+	}
+	return uuid.Parse(id)
+}
@@ -0,0 +1,98 @@
+package log
+
+import (
+	"context"
+	"sync"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+)
+
+type identityKey struct{}
+
+type identity struct {
+	mu      sync.Mutex
+	userID  string
+	resolve IdentityFunc
+}
+
+// IdentityFunc returns the user a request is made on behalf of, or an
+// empty string if it cannot tell. Without one, the user is only known once
+// a service calls SetUserID.
+type IdentityFunc func(req any) string
+
+func withIdentity(ctx context.Context, f IdentityFunc) (context.Context, *identity) {
+	id := &identity{resolve: f}
+	return context.WithValue(ctx, identityKey{}, id), id
+}
+
+func (i *identity) setFromRequest(req any) {
+	if i.resolve == nil {
+		return
+	}
+	userID := i.resolve(req)
+	if userID == "" {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.userID = userID
+}
+
+// SetUserID attaches the user of the current call to its log fields, once
+// it is known to be who they claim.
+func SetUserID(ctx context.Context, userID string) {
+	id, ok := ctx.Value(identityKey{}).(*identity)
+	if !ok {
+		return
+	}
+	id.mu.Lock()
+	defer id.mu.Unlock()
+	id.userID = userID
+}
+
+func IdentityFields(ctx context.Context) logging.Fields {
+	id, ok := ctx.Value(identityKey{}).(*identity)
+	if !ok {
+		return nil
+	}
+	id.mu.Lock()
+	defer id.mu.Unlock()
+	if id.userID == "" {
+		return nil
+	}
+	return logging.Fields{"user.id", id.userID}
+}
+
+func UnaryIdentityInterceptor(f IdentityFunc) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, id := withIdentity(ctx, f)
+		id.setFromRequest(req)
+		return handler(ctx, req)
+	}
+}
+
+func StreamIdentityInterceptor(f IdentityFunc) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, id := withIdentity(ss.Context(), f)
+		return handler(srv, &identityStream{ServerStream: ss, ctx: ctx, id: id})
+	}
+}
+
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	id  *identity
+}
+
+func (s *identityStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *identityStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.id.setFromRequest(m)
+	return nil
+}
@@ -27,6 +27,14 @@ func UserToPB(u server.User) *adminpb.User {
 	}
 }
 
+func UserStatesFromPB(pbs []adminpb.UserState) []server.UserState {
+	states := make([]server.UserState, 0, len(pbs))
+	for _, pb := range pbs {
+		states = append(states, userStateFromPB(pb))
+	}
+	return states
+}
+
 func userStateFromPB(pb adminpb.UserState) server.UserState {
 	switch pb {
 	case adminpb.UserState_USER_STATE_REGISTERED:
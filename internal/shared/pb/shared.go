@@ -1,14 +1,20 @@
 package shared
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+
 	sharedpb "github.com/charadev96/gonec/gen/shared"
 	shared "github.com/charadev96/gonec/internal/shared/domain"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func SessionFromPB(pb *sharedpb.Session) (shared.Session, error) {
+	if pb == nil {
+		return shared.Session{}, fmt.Errorf("missing session: %w", shared.ErrInvalid)
+	}
 	id, err := UUIDFromPB(pb.Id)
 	if err != nil {
 		return shared.Session{}, err
@@ -32,9 +38,30 @@ func SessionToPB(s shared.Session) *sharedpb.Session {
 	}
 }
 
+func UserIdentityFromPB(pb *sharedpb.UserIdentity) (shared.UserIdentity, error) {
+	id, err := UUIDFromPB(pb.Id)
+	if err != nil {
+		return shared.UserIdentity{}, err
+	}
+	return shared.UserIdentity{
+		ID:        id,
+		Name:      pb.Name,
+		PublicKey: pb.PublicKey,
+	}, nil
+}
+
+func UserIdentityToPB(u shared.UserIdentity) *sharedpb.UserIdentity {
+	return &sharedpb.UserIdentity{
+		Id:        UUIDToPB(u.ID),
+		Name:      u.Name,
+		PublicKey: u.PublicKey,
+	}
+}
+
 func ServerIdentityFromPB(pb *sharedpb.ServerIdentity) shared.ServerIdentity {
 	return shared.ServerIdentity{
 		IPAddress: pb.IpAddress,
+		Host:      pb.Host,
 		PublicKey: pb.PublicKey,
 	}
 }
@@ -42,10 +69,41 @@ func ServerIdentityFromPB(pb *sharedpb.ServerIdentity) shared.ServerIdentity {
 func ServerIdentityToPB(s shared.ServerIdentity) *sharedpb.ServerIdentity {
 	return &sharedpb.ServerIdentity{
 		IpAddress: s.IPAddress,
+		Host:      s.Host,
 		PublicKey: s.PublicKey,
 	}
 }
 
+func KeyRolloverFromPB(pb *sharedpb.KeyRollover) (shared.KeyRollover, error) {
+	if pb == nil {
+		return shared.KeyRollover{}, fmt.Errorf("missing key rollover: %w", shared.ErrInvalid)
+	}
+	var notBefore, notAfter time.Time
+	if pb.NotBefore != nil {
+		notBefore = pb.NotBefore.AsTime()
+	}
+	if pb.NotAfter != nil {
+		notAfter = pb.NotAfter.AsTime()
+	}
+	return shared.KeyRollover{
+		OldKey:    pb.OldKey,
+		NewKey:    pb.NewKey,
+		NotBefore: notBefore,
+		NotAfter:  notAfter,
+		Signature: pb.Signature,
+	}, nil
+}
+
+func KeyRolloverToPB(r shared.KeyRollover) *sharedpb.KeyRollover {
+	return &sharedpb.KeyRollover{
+		OldKey:    r.OldKey,
+		NewKey:    r.NewKey,
+		NotBefore: timestamppb.New(r.NotBefore),
+		NotAfter:  timestamppb.New(r.NotAfter),
+		Signature: r.Signature,
+	}
+}
+
 func InviteCredentialFromPB(pb *sharedpb.InviteCredential) (shared.InviteCredential, error) {
 	var notBefore, notAfter time.Time
 	if pb.NotBefore != nil {
@@ -98,15 +156,339 @@ func MessageFromPB(pb *sharedpb.Message) (shared.Message, error) {
 	if err != nil {
 		return shared.Message{}, err
 	}
+	// Servers predating message IDs leave them and the recipient out.
+	var id, recipient uuid.UUID
+	if pb.Id != "" {
+		if id, err = UUIDFromPB(pb.Id); err != nil {
+			return shared.Message{}, err
+		}
+	}
+	if pb.Recipient != "" {
+		if recipient, err = UUIDFromPB(pb.Recipient); err != nil {
+			return shared.Message{}, err
+		}
+	}
+	var sentAt, editedAt, deletedAt time.Time
+	if pb.SentAt != nil {
+		sentAt = pb.SentAt.AsTime()
+	}
+	if pb.EditedAt != nil {
+		editedAt = pb.EditedAt.AsTime()
+	}
+	if pb.DeletedAt != nil {
+		deletedAt = pb.DeletedAt.AsTime()
+	}
+	var as []shared.Attachment
+	for _, a := range pb.Attachments {
+		attachment, err := AttachmentFromPB(a)
+		if err != nil {
+			return shared.Message{}, err
+		}
+		as = append(as, attachment)
+	}
+	var replyTo, thread uuid.UUID
+	if pb.ReplyTo != "" {
+		if replyTo, err = UUIDFromPB(pb.ReplyTo); err != nil {
+			return shared.Message{}, err
+		}
+		if thread, err = UUIDFromPB(pb.ThreadId); err != nil {
+			return shared.Message{}, err
+		}
+	}
+	var rs []shared.ReactionCount
+	for _, r := range pb.Reactions {
+		rs = append(rs, shared.ReactionCount{Emoji: r.Emoji, Count: int(r.Count)})
+	}
 	return shared.Message{
-		Sender:  sender,
-		Content: pb.Content,
+		ID:          id,
+		Sender:      sender,
+		Recipient:   recipient,
+		Content:     pb.Content,
+		SentAt:      sentAt,
+		EditedAt:    editedAt,
+		DeletedAt:   deletedAt,
+		Attachments: as,
+		Reactions:   rs,
+		ReplyTo:     replyTo,
+		ThreadID:    thread,
 	}, nil
 }
 
 func MessageToPB(m shared.Message) *sharedpb.Message {
-	return &sharedpb.Message{
+	pb := &sharedpb.Message{
 		Sender:  UUIDToPB(m.Sender),
 		Content: m.Content,
 	}
+	if m.ID != uuid.Nil {
+		pb.Id = UUIDToPB(m.ID)
+	}
+	if m.Recipient != uuid.Nil {
+		pb.Recipient = UUIDToPB(m.Recipient)
+	}
+	if !m.SentAt.IsZero() {
+		pb.SentAt = timestamppb.New(m.SentAt)
+	}
+	if !m.EditedAt.IsZero() {
+		pb.EditedAt = timestamppb.New(m.EditedAt)
+	}
+	if !m.DeletedAt.IsZero() {
+		pb.DeletedAt = timestamppb.New(m.DeletedAt)
+	}
+	for _, a := range m.Attachments {
+		pb.Attachments = append(pb.Attachments, AttachmentToPB(a))
+	}
+	for _, r := range m.Reactions {
+		pb.Reactions = append(pb.Reactions, &sharedpb.ReactionCount{
+			Emoji: r.Emoji,
+			Count: uint32(r.Count),
+		})
+	}
+	if m.ReplyTo != uuid.Nil {
+		pb.ReplyTo = UUIDToPB(m.ReplyTo)
+		pb.ThreadId = UUIDToPB(m.ThreadID)
+	}
+	return pb
+}
+
+func MessagesToPB(ms []shared.Message) []*sharedpb.Message {
+	pbs := make([]*sharedpb.Message, 0, len(ms))
+	for _, m := range ms {
+		pbs = append(pbs, MessageToPB(m))
+	}
+	return pbs
+}
+
+func MessageEditFromPB(pb *sharedpb.MessageEdit) (shared.MessageEdit, error) {
+	id, err := UUIDFromPB(pb.Id)
+	if err != nil {
+		return shared.MessageEdit{}, err
+	}
+	sender, err := UUIDFromPB(pb.Sender)
+	if err != nil {
+		return shared.MessageEdit{}, err
+	}
+	return shared.MessageEdit{
+		ID:       id,
+		Sender:   sender,
+		Content:  pb.Content,
+		EditedAt: pb.EditedAt.AsTime(),
+	}, nil
+}
+
+func MessageEditToPB(e shared.MessageEdit) *sharedpb.MessageEdit {
+	return &sharedpb.MessageEdit{
+		Id:       UUIDToPB(e.ID),
+		Sender:   UUIDToPB(e.Sender),
+		Content:  e.Content,
+		EditedAt: timestamppb.New(e.EditedAt),
+	}
+}
+
+func MessageDeleteFromPB(pb *sharedpb.MessageDelete) (shared.MessageDelete, error) {
+	id, err := UUIDFromPB(pb.Id)
+	if err != nil {
+		return shared.MessageDelete{}, err
+	}
+	sender, err := UUIDFromPB(pb.Sender)
+	if err != nil {
+		return shared.MessageDelete{}, err
+	}
+	return shared.MessageDelete{
+		ID:        id,
+		Sender:    sender,
+		DeletedAt: pb.DeletedAt.AsTime(),
+	}, nil
+}
+
+func MessageDeleteToPB(d shared.MessageDelete) *sharedpb.MessageDelete {
+	return &sharedpb.MessageDelete{
+		Id:        UUIDToPB(d.ID),
+		Sender:    UUIDToPB(d.Sender),
+		DeletedAt: timestamppb.New(d.DeletedAt),
+	}
+}
+
+func ReactionFromPB(pb *sharedpb.Reaction) (shared.Reaction, error) {
+	message, err := UUIDFromPB(pb.MessageId)
+	if err != nil {
+		return shared.Reaction{}, err
+	}
+	user, err := UUIDFromPB(pb.UserId)
+	if err != nil {
+		return shared.Reaction{}, err
+	}
+	return shared.Reaction{
+		MessageID: message,
+		UserID:    user,
+		Emoji:     pb.Emoji,
+		Removed:   pb.Removed,
+		ReactedAt: pb.ReactedAt.AsTime(),
+	}, nil
+}
+
+func ReactionToPB(r shared.Reaction) *sharedpb.Reaction {
+	return &sharedpb.Reaction{
+		MessageId: UUIDToPB(r.MessageID),
+		UserId:    UUIDToPB(r.UserID),
+		Emoji:     r.Emoji,
+		Removed:   r.Removed,
+		ReactedAt: timestamppb.New(r.ReactedAt),
+	}
+}
+
+func NoticeFromPB(pb *sharedpb.Notice) (shared.Notice, error) {
+	id, err := UUIDFromPB(pb.Id)
+	if err != nil {
+		return shared.Notice{}, err
+	}
+	return shared.Notice{
+		ID:      id,
+		Content: pb.Content,
+		SentAt:  pb.SentAt.AsTime(),
+	}, nil
+}
+
+func NoticeToPB(n shared.Notice) *sharedpb.Notice {
+	return &sharedpb.Notice{
+		Id:      UUIDToPB(n.ID),
+		Content: n.Content,
+		SentAt:  timestamppb.New(n.SentAt),
+	}
+}
+
+func BlockFromPB(pb *sharedpb.Block) (shared.Block, error) {
+	user, err := UUIDFromPB(pb.UserId)
+	if err != nil {
+		return shared.Block{}, err
+	}
+	return shared.Block{
+		UserID:    user,
+		BlockedAt: pb.BlockedAt.AsTime(),
+	}, nil
+}
+
+func BlockToPB(b shared.Block) *sharedpb.Block {
+	return &sharedpb.Block{
+		UserId:    UUIDToPB(b.UserID),
+		BlockedAt: timestamppb.New(b.BlockedAt),
+	}
+}
+
+func AttachmentFromPB(pb *sharedpb.Attachment) (shared.Attachment, error) {
+	id, err := UUIDFromPB(pb.Id)
+	if err != nil {
+		return shared.Attachment{}, err
+	}
+	return shared.Attachment{
+		ID:       id,
+		Size:     int64(pb.Size),
+		MIMEType: pb.MimeType,
+		Hash:     pb.Hash,
+	}, nil
+}
+
+func AttachmentToPB(a shared.Attachment) *sharedpb.Attachment {
+	return &sharedpb.Attachment{
+		Id:       UUIDToPB(a.ID),
+		Size:     uint64(a.Size),
+		MimeType: a.MIMEType,
+		Hash:     a.Hash,
+	}
+}
+
+func PresenceFromPB(pb *sharedpb.Presence) (shared.Presence, error) {
+	id, err := UUIDFromPB(pb.UserId)
+	if err != nil {
+		return shared.Presence{}, err
+	}
+	var lastSeen time.Time
+	if pb.LastSeen != nil {
+		lastSeen = pb.LastSeen.AsTime()
+	}
+	return shared.Presence{
+		UserID:   id,
+		Online:   pb.Online,
+		LastSeen: lastSeen,
+	}, nil
+}
+
+func PresenceToPB(p shared.Presence) *sharedpb.Presence {
+	pb := &sharedpb.Presence{
+		UserId: UUIDToPB(p.UserID),
+		Online: p.Online,
+	}
+	if !p.LastSeen.IsZero() {
+		pb.LastSeen = timestamppb.New(p.LastSeen)
+	}
+	return pb
+}
+
+func PresencesToPB(ps []shared.Presence) []*sharedpb.Presence {
+	pbs := make([]*sharedpb.Presence, 0, len(ps))
+	for _, p := range ps {
+		pbs = append(pbs, PresenceToPB(p))
+	}
+	return pbs
+}
+
+func EventFromPB(pb *sharedpb.Event) (shared.Event, error) {
+	switch p := pb.Payload.(type) {
+	case *sharedpb.Event_Message:
+		m, err := MessageFromPB(p.Message)
+		if err != nil {
+			return shared.Event{}, err
+		}
+		return shared.Event{Message: &m}, nil
+	case *sharedpb.Event_Presence:
+		presence, err := PresenceFromPB(p.Presence)
+		if err != nil {
+			return shared.Event{}, err
+		}
+		return shared.Event{Presence: &presence}, nil
+	case *sharedpb.Event_Edit:
+		edit, err := MessageEditFromPB(p.Edit)
+		if err != nil {
+			return shared.Event{}, err
+		}
+		return shared.Event{Edit: &edit}, nil
+	case *sharedpb.Event_Delete:
+		del, err := MessageDeleteFromPB(p.Delete)
+		if err != nil {
+			return shared.Event{}, err
+		}
+		return shared.Event{Delete: &del}, nil
+	case *sharedpb.Event_Reaction:
+		r, err := ReactionFromPB(p.Reaction)
+		if err != nil {
+			return shared.Event{}, err
+		}
+		return shared.Event{Reaction: &r}, nil
+	case *sharedpb.Event_Notice:
+		n, err := NoticeFromPB(p.Notice)
+		if err != nil {
+			return shared.Event{}, err
+		}
+		return shared.Event{Notice: &n}, nil
+	default:
+		return shared.Event{}, fmt.Errorf("unknown event payload %T: %w", p, shared.ErrInvalid)
+	}
+}
+
+func EventToPB(e shared.Event) *sharedpb.Event {
+	switch {
+	case e.Message != nil:
+		return &sharedpb.Event{Payload: &sharedpb.Event_Message{Message: MessageToPB(*e.Message)}}
+	case e.Presence != nil:
+		return &sharedpb.Event{Payload: &sharedpb.Event_Presence{Presence: PresenceToPB(*e.Presence)}}
+	case e.Edit != nil:
+		return &sharedpb.Event{Payload: &sharedpb.Event_Edit{Edit: MessageEditToPB(*e.Edit)}}
+	case e.Delete != nil:
+		return &sharedpb.Event{Payload: &sharedpb.Event_Delete{Delete: MessageDeleteToPB(*e.Delete)}}
+	case e.Reaction != nil:
+		return &sharedpb.Event{Payload: &sharedpb.Event_Reaction{Reaction: ReactionToPB(*e.Reaction)}}
+	case e.Notice != nil:
+		return &sharedpb.Event{Payload: &sharedpb.Event_Notice{Notice: NoticeToPB(*e.Notice)}}
+	default:
+		return &sharedpb.Event{}
+	}
 }
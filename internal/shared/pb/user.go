@@ -0,0 +1,150 @@
+package shared
+
+import (
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	userpb "github.com/charadev96/gonec/gen/user"
+	client "github.com/charadev96/gonec/internal/client/domain"
+	shared "github.com/charadev96/gonec/internal/shared/domain"
+)
+
+func PendingTrustToPB(p client.PendingTrust) *userpb.PendingTrust {
+	return &userpb.PendingTrust{
+		ConnectionId: p.ConnID,
+		PublicKey:    p.PublicKey,
+		Fingerprint:  p.Fingerprint,
+	}
+}
+
+func ConnMessageToPB(m client.ConnMessage) *userpb.ListenReply {
+	if m.Event != nil {
+		return &userpb.ListenReply{
+			ConnectionId: m.ConnID,
+			Payload:      &userpb.ListenReply_Event{Event: connEventToPB(*m.Event)},
+		}
+	}
+	return &userpb.ListenReply{
+		ConnectionId: m.ConnID,
+		Payload:      &userpb.ListenReply_Message{Message: MessageToPB(m.Message)},
+	}
+}
+
+func ConnUpdateToPB(u client.ConnUpdate) *userpb.EventsReply {
+	if u.Conn != nil {
+		return &userpb.EventsReply{
+			ConnectionId: u.ConnID,
+			Payload:      &userpb.EventsReply_Connection{Connection: connEventToPB(*u.Conn)},
+		}
+	}
+	return &userpb.EventsReply{
+		ConnectionId: u.ConnID,
+		Payload:      &userpb.EventsReply_Event{Event: EventToPB(u.Event)},
+	}
+}
+
+func connEventToPB(e client.ConnEvent) *userpb.ConnectionEvent {
+	ev := &userpb.ConnectionEvent{
+		State:   connStateToPB(e.State),
+		Attempt: uint32(e.Attempt),
+	}
+	if e.Err != nil {
+		ev.Error = e.Err.Error()
+	}
+	return ev
+}
+
+func connStateToPB(s client.ConnState) userpb.ConnectionState {
+	switch s {
+	case client.ConnReconnecting:
+		return userpb.ConnectionState_CONNECTION_STATE_RECONNECTING
+	case client.ConnConnected:
+		return userpb.ConnectionState_CONNECTION_STATE_CONNECTED
+	case client.ConnDisconnected:
+		return userpb.ConnectionState_CONNECTION_STATE_DISCONNECTED
+	default:
+		return userpb.ConnectionState_CONNECTION_STATE_UNSPECIFIED
+	}
+}
+
+func StoredMessageToPB(m client.StoredMessage) *userpb.StoredMessage {
+	pb := &userpb.StoredMessage{
+		Id:           m.ID,
+		ConnectionId: m.ConnID,
+		Peer:         m.Peer.String(),
+		Direction:    directionToPB(m.Direction),
+		Content:      m.Content,
+		CreatedAt:    timestamppb.New(m.CreatedAt),
+	}
+	if m.MessageID != uuid.Nil {
+		pb.MessageId = m.MessageID.String()
+	}
+	if !m.EditedAt.IsZero() {
+		pb.EditedAt = timestamppb.New(m.EditedAt)
+	}
+	if !m.DeletedAt.IsZero() {
+		pb.DeletedAt = timestamppb.New(m.DeletedAt)
+	}
+	if m.ReplyTo != uuid.Nil {
+		pb.ReplyTo = m.ReplyTo.String()
+		pb.ThreadId = m.ThreadID.String()
+	}
+	for _, r := range m.Reactions {
+		pb.Reactions = append(pb.Reactions, &userpb.ReactionCount{
+			Emoji: r.Emoji,
+			Count: uint32(r.Count),
+		})
+	}
+	return pb
+}
+
+func StoredMessagesToPB(ms []client.StoredMessage) []*userpb.StoredMessage {
+	pbs := make([]*userpb.StoredMessage, len(ms))
+	for i, m := range ms {
+		pbs[i] = StoredMessageToPB(m)
+	}
+	return pbs
+}
+
+func directionToPB(d client.Direction) userpb.MessageDirection {
+	switch d {
+	case client.DirectionSent:
+		return userpb.MessageDirection_MESSAGE_DIRECTION_SENT
+	case client.DirectionReceived:
+		return userpb.MessageDirection_MESSAGE_DIRECTION_RECEIVED
+	default:
+		return userpb.MessageDirection_MESSAGE_DIRECTION_UNSPECIFIED
+	}
+}
+
+// ConnPinToPB leaves out the private key of the pin.
+func ConnPinToPB(p client.ConnPin) *userpb.Pin {
+	return &userpb.Pin{
+		ConnectionId:      p.ID,
+		ServerAddress:     p.Server.Addr(),
+		ServerFingerprint: shared.KeyFingerprint(p.Server.PublicKey),
+		UserId:            p.User.ID.String(),
+		UserName:          p.User.Name,
+	}
+}
+
+func ConflictPolicyFromPB(p userpb.ConflictPolicy) client.ConflictPolicy {
+	switch p {
+	case userpb.ConflictPolicy_CONFLICT_POLICY_SKIP:
+		return client.ConflictSkip
+	case userpb.ConflictPolicy_CONFLICT_POLICY_OVERWRITE:
+		return client.ConflictOverwrite
+	case userpb.ConflictPolicy_CONFLICT_POLICY_RENAME:
+		return client.ConflictRename
+	default:
+		return client.ConflictFail
+	}
+}
+
+func ImportResultToPB(r client.ImportResult) *userpb.ImportedPin {
+	return &userpb.ImportedPin{
+		ConnectionId: r.ConnID,
+		ImportedAs:   r.ImportedAs,
+		Skipped:      r.Skipped,
+	}
+}